@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Backend identifies a HIDAPI backend.
+type Backend int
+
+const (
+	BackendHidraw  Backend = iota + 1 // Linux hidraw
+	BackendLibusb                     // libusb
+	BackendDarwin                     // macOS IOHIDManager
+	BackendWindows                    // Windows HID
+)
+
+var backendNames = map[Backend]string{
+	BackendHidraw:  "hidraw",
+	BackendLibusb:  "libusb",
+	BackendDarwin:  "darwin",
+	BackendWindows: "windows",
+}
+
+func (b Backend) String() string {
+	if name, ok := backendNames[b]; ok {
+		return name
+	}
+	return fmt.Sprintf("Backend(%d)", int(b))
+}
+
+var (
+	backendMu       sync.Mutex
+	enabledBackends []Backend
+)
+
+// Backends returns the backends compiled into the program. The first backend
+// is the default used by Enumerate, Open, and OpenFirst.
+//
+// On Linux, the hidraw backend is always available. Programs built with the
+// libusb build constraint contain both backends; libusb is the default. In
+// this case, hidraw devices are enumerated by HIDAPI and accessed in Go.
+func Backends() []Backend {
+	return append([]Backend(nil), sysBackends...)
+}
+
+// hasBackend reports whether the backend b is compiled into the program.
+func hasBackend(b Backend) bool {
+	for _, sb := range sysBackends {
+		if b == sb {
+			return true
+		}
+	}
+	return false
+}
+
+// SetBackends selects the backends used by Enumerate, Open, and OpenFirst.
+// Enumerate visits the devices of each backend in the order given, which
+// allows results from multiple backends to be merged; Open and OpenFirst
+// open the first matching device. If no backends are given, the default
+// backend is selected. An error is returned if a backend is not compiled into
+// the program.
+func SetBackends(backends ...Backend) error {
+	for _, b := range backends {
+		if !hasBackend(b) {
+			return fmt.Errorf("%s backend not available", b)
+		}
+	}
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	enabledBackends = append([]Backend(nil), backends...)
+	return nil
+}
+
+// selectedBackends returns the backends selected by SetBackends.
+func selectedBackends() []Backend {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	if len(enabledBackends) == 0 {
+		return sysBackends[:1]
+	}
+	return enabledBackends
+}
+
+// EnumerateBackend is like Enumerate, but only visits devices available from
+// the backend b.
+func EnumerateBackend(b Backend, vid, pid uint16, enumFn EnumFunc) error {
+	if !hasBackend(b) {
+		return fmt.Errorf("%s backend not available", b)
+	}
+	return enumerate([]Backend{b}, vid, pid, enumFn)
+}
+
+// splitPath returns the backend selected by the device path and the path
+// without its backend prefix. Paths may be prefixed by a backend name and a
+// colon (e.g. libusb:1-2:1.0); otherwise, the backend is inferred from the
+// form of the path.
+func splitPath(path string) (Backend, string) {
+	if i := strings.IndexByte(path, ':'); i > 0 {
+		for b, name := range backendNames {
+			if path[:i] == name {
+				return b, path[i+1:]
+			}
+		}
+	}
+	if len(sysBackends) > 1 {
+		// Only the libusb and hidraw backends may be combined.
+		if libusbPathRegexp.MatchString(path) {
+			return BackendLibusb, path
+		}
+		return BackendHidraw, path
+	}
+	return sysBackends[0], path
+}
+
+var (
+	errMu     sync.Mutex
+	globalErr error
+)
+
+// setError records err as the last non-device-specific error reported by a Go
+// backend and returns it.
+func setError(err error) error {
+	errMu.Lock()
+	globalErr = err
+	errMu.Unlock()
+	return err
+}
+
+// openError returns the last non-device-specific error recorded by setError.
+func openError() error {
+	errMu.Lock()
+	defer errMu.Unlock()
+	return globalErr
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import "testing"
+
+func TestSplitPath(t *testing.T) {
+	// Unprefixed paths which are not libusb paths are opened using hidraw
+	// when both backends are available.
+	other := sysBackends[0]
+	if len(sysBackends) > 1 {
+		other = BackendHidraw
+	}
+	tests := []struct {
+		path    string
+		backend Backend
+		want    string
+	}{
+		{"libusb:1-2:1.0", BackendLibusb, "1-2:1.0"},
+		{"hidraw:/dev/hidraw0", BackendHidraw, "/dev/hidraw0"},
+		{"DevSrvsID:4294969113", other, "DevSrvsID:4294969113"},
+	}
+	for _, tt := range tests {
+		b, path := splitPath(tt.path)
+		if b != tt.backend || path != tt.want {
+			t.Errorf("%s: got (%s, %q), want (%s, %q)", tt.path, b, path, tt.backend, tt.want)
+		}
+	}
+}
+
+func TestSetBackends(t *testing.T) {
+	defer SetBackends()
+
+	if err := SetBackends(Backend(0)); err == nil {
+		t.Error("expected error for unavailable backend")
+	}
+	if got := selectedBackends(); len(got) != 1 || got[0] != sysBackends[0] {
+		t.Errorf("got %v, want default backend %s", got, sysBackends[0])
+	}
+
+	backends := Backends()
+	if err := SetBackends(backends...); err != nil {
+		t.Fatal(err)
+	}
+	if got := selectedBackends(); len(got) != len(backends) {
+		t.Errorf("got %v, want %v", got, backends)
+	}
+	if _, err := OpenPath("unknown:path"); err == nil {
+		t.Error("expected error opening unknown path")
+	}
+	if !hasBackend(BackendLibusb) {
+		if _, err := OpenPath("libusb:1-2:1.0"); err == nil {
+			t.Error("expected error for unavailable backend")
+		}
+	}
+}
@@ -21,6 +21,8 @@
 // OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
 // SUCH DAMAGE.
 
+//go:build !(linux && (purego || (nolibudev && !libusb && !systemhidapi)))
+
 package hid
 
 /*
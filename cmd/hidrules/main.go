@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:generate doxxer . -h
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/sstallion/go-hid"
+	"github.com/sstallion/go-tools/util"
+)
+
+var (
+	vidFlag      uint
+	pidFlag      uint
+	serialFlag   string
+	busFlag      string
+	attachedFlag bool
+	modeFlag     string
+	groupFlag    string
+	uaccessFlag  bool
+	symlinkFlag  string
+	backendFlag  string
+	outputFlag   string
+)
+
+const header = `# Generated by hidrules. Install as /etc/udev/rules.d/70-hid.rules or another
+# file ordered before 73-seat-late.rules, then reload rules and reconnect devices:
+#
+#   udevadm control --reload-rules && udevadm trigger
+`
+
+func usage() {
+	util.PrintGlobalUsage(`
+Hidrules generates udev rules granting access to HID devices.
+
+Usage:
+
+  {{ .Program }} [-attached] [-vid vendor] [-pid product] [-serial serial] [-bus type]
+          [-mode mode] [-group group] [-uaccess=false] [-symlink prefix]
+          [-backend backend] [-o file]
+
+Rules are generated for devices matching the vendor ID, product ID, and serial
+number given; if -attached is specified, rules are generated for each matching
+device attached to the system instead. Attached devices on any bus match unless
+-bus is specified.
+
+Flags:
+
+  {{ call .PrintDefaults }}
+
+Report issues to https://github.com/sstallion/go-hid/issues.
+`)
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "%s: %v\n", util.Program(), err)
+	os.Exit(1)
+}
+
+// isFlagSet reports whether the flag named name was set on the command line.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// devices returns the devices for which rules are generated.
+func devices(bus hid.BusType) ([]*hid.DeviceInfo, error) {
+	vid, pid := uint16(vidFlag), uint16(pidFlag)
+	if !attachedFlag {
+		if vid == hid.VendorIDAny {
+			return nil, fmt.Errorf("-vid is required unless -attached is specified")
+		}
+		return []*hid.DeviceInfo{{
+			VendorID:  vid,
+			ProductID: pid,
+			SerialNbr: serialFlag,
+			BusType:   bus,
+		}}, nil
+	}
+
+	matchBus := isFlagSet("bus")
+	var devs []*hid.DeviceInfo
+	err := hid.Enumerate(vid, pid, func(info *hid.DeviceInfo) error {
+		if matchBus && info.BusType != bus {
+			return nil
+		}
+		if serialFlag == "" || serialFlag == info.SerialNbr {
+			devs = append(devs, info)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(devs) == 0 {
+		return nil, fmt.Errorf("no matching devices attached")
+	}
+	return devs, nil
+}
+
+// writeRules writes the header and the rules for devs to w.
+func writeRules(w io.Writer, devs []*hid.DeviceInfo, opts *hid.UdevOptions) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, header)
+	if err := hid.WriteUdevRules(bw, devs, opts); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeFile writes the rules for devs to the file named by path. Rules are
+// written to a temporary file in the same directory, which replaces path once
+// complete so that a partially written file is never left behind.
+func writeFile(path string, devs []*hid.DeviceInfo, opts *hid.UdevOptions) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	err = writeRules(f, devs, opts)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+func main() {
+	flag.Usage = usage
+	flag.BoolVar(&attachedFlag, "attached", false, "Generate rules for matching devices attached to the system")
+	flag.UintVar(&vidFlag, "vid", hid.VendorIDAny, "Match devices with `vendor` ID")
+	flag.UintVar(&pidFlag, "pid", hid.ProductIDAny, "Match devices with `product` ID")
+	flag.StringVar(&serialFlag, "serial", "", "Match devices with `serial` number")
+	flag.StringVar(&busFlag, "bus", "usb", "Match devices with bus `type` (usb, bluetooth, i2c, spi)")
+	flag.StringVar(&modeFlag, "mode", "0660", "Set device node `mode` (octal)")
+	flag.StringVar(&groupFlag, "group", "", "Set device node `group`")
+	flag.BoolVar(&uaccessFlag, "uaccess", true, "Grant access to the user of the active local session")
+	flag.StringVar(&symlinkFlag, "symlink", "", "Create symbolic links named by `prefix` and serial number")
+	flag.StringVar(&backendFlag, "backend", "all", "Generate rules for `backend` (hidraw, libusb, all)")
+	flag.StringVar(&outputFlag, "o", "", "Write rules to `file` (default standard output)")
+	flag.Parse()
+
+	if vidFlag > math.MaxUint16 {
+		fatal(fmt.Errorf("invalid vendor ID: %#x", vidFlag))
+	}
+	if pidFlag > math.MaxUint16 {
+		fatal(fmt.Errorf("invalid product ID: %#x", pidFlag))
+	}
+	bus, err := hid.ParseBusType(busFlag)
+	if err != nil {
+		fatal(err)
+	}
+	mode, err := strconv.ParseUint(modeFlag, 8, 32)
+	if err != nil || mode > 0777 {
+		fatal(fmt.Errorf("invalid mode: %s", modeFlag))
+	}
+	opts := &hid.UdevOptions{
+		Mode:    os.FileMode(mode),
+		Group:   groupFlag,
+		Uaccess: uaccessFlag,
+		Symlink: symlinkFlag,
+	}
+	switch backendFlag {
+	case "hidraw":
+		opts.Hidraw = true
+	case "libusb":
+		opts.Libusb = true
+	case "all":
+		opts.Hidraw, opts.Libusb = true, true
+	default:
+		fatal(fmt.Errorf("invalid backend: %s", backendFlag))
+	}
+
+	devs, err := devices(bus)
+	if err != nil {
+		fatal(err)
+	}
+
+	if outputFlag != "" {
+		err = writeFile(outputFlag, devs, opts)
+	} else {
+		err = writeRules(os.Stdout, devs, opts)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
@@ -5,11 +5,17 @@ Lshid lists HID devices attached to the system.
 
 Usage:
 
-	lshid [-V] [-v] [-vid vendor] [-pid product]
+	lshid [-V] [-v] [-check] [-vid vendor] [-pid product] [-ids file] [-backend list]
 
 Flags:
 
 	-V	Print HIDAPI version and exit
+	-backend list
+	  	Enumerate devices using comma-separated list of backends (e.g. libusb,hidraw)
+	-check
+	  	Open each device and explain failures
+	-ids file
+	  	Resolve names using usb.ids file (default system or embedded copy)
 	-pid product
 	  	Show devices with matching product ID
 	-v	Increase verbosity (show device information)
@@ -31,6 +31,7 @@ import (
 	"strings"
 
 	"github.com/sstallion/go-hid"
+	"github.com/sstallion/go-hid/usbids"
 	"github.com/sstallion/go-tools/util"
 )
 
@@ -46,8 +47,11 @@ func (versionFlag) Set(s string) error {
 
 var (
 	verboseFlag bool
+	checkFlag   bool
 	vidFlag     uint
 	pidFlag     uint
+	idsFlag     string
+	backendFlag string
 )
 
 func fmtRelease(n uint16) string {
@@ -57,6 +61,18 @@ func fmtRelease(n uint16) string {
 	return fmt.Sprintf("%#04x (%x.%x)", n, n>>8, n&0xff)
 }
 
+func fmtCollection(c hid.Collection) string {
+	s := fmt.Sprintf("%#04x:%#04x", c.UsagePage, c.Usage)
+	if len(c.ReportIDs) > 0 {
+		ids := make([]string, len(c.ReportIDs))
+		for i, id := range c.ReportIDs {
+			ids[i] = fmt.Sprint(id)
+		}
+		s += fmt.Sprintf(" (Report IDs %s)", strings.Join(ids, ", "))
+	}
+	return s
+}
+
 func fmtString(s string) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -65,13 +81,59 @@ func fmtString(s string) string {
 	return s
 }
 
+// indent returns s with each non-empty line indented by a tab.
+func indent(s string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(s, "\n") {
+		if line != "" {
+			b.WriteString("\t")
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// names returns the manufacturer and product strings for the device described
+// by info. Empty strings reported by USB devices are resolved using the usb.ids
+// database.
+func names(info *hid.DeviceInfo) (mfr, product string) {
+	mfr, product = strings.TrimSpace(info.MfrStr), strings.TrimSpace(info.ProductStr)
+	if info.BusType == hid.BusUSB {
+		if mfr == "" {
+			mfr, _ = usbids.Vendor(info.VendorID)
+		}
+		if product == "" {
+			product, _ = usbids.Product(info.VendorID, info.ProductID)
+		}
+	}
+	return
+}
+
+// backends returns the backends named by the comma-separated list s.
+func backends(s string) ([]hid.Backend, error) {
+	var bs []hid.Backend
+	for _, name := range strings.Split(s, ",") {
+		found := false
+		for _, b := range hid.Backends() {
+			if b.String() == name {
+				bs, found = append(bs, b), true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s backend not available", name)
+		}
+	}
+	return bs, nil
+}
+
 func usage() {
 	util.PrintGlobalUsage(`
 Lshid lists HID devices attached to the system.
 
 Usage:
 
-  {{ .Program }} [-V] [-v] [-vid vendor] [-pid product]
+  {{ .Program }} [-V] [-v] [-check] [-vid vendor] [-pid product] [-ids file] [-backend list]
 
 Flags:
 
@@ -85,14 +147,47 @@ func main() {
 	flag.Usage = usage
 	flag.Var(versionFlag{}, "V", "Print HIDAPI version and exit")
 	flag.BoolVar(&verboseFlag, "v", false, "Increase verbosity (show device information)")
+	flag.BoolVar(&checkFlag, "check", false, "Open each device and explain failures")
 	flag.UintVar(&vidFlag, "vid", hid.VendorIDAny, "Show devices with matching `vendor` ID")
 	flag.UintVar(&pidFlag, "pid", hid.ProductIDAny, "Show devices with matching `product` ID")
+	flag.StringVar(&idsFlag, "ids", "", "Resolve names using usb.ids `file` (default system or embedded copy)")
+	flag.StringVar(&backendFlag, "backend", "", "Enumerate devices using comma-separated `list` of backends (e.g. libusb,hidraw)")
 	flag.Parse()
 
+	if backendFlag != "" {
+		bs, err := backends(backendFlag)
+		if err == nil {
+			err = hid.SetBackends(bs...)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", util.Program(), err)
+			os.Exit(1)
+		}
+	}
+
+	if idsFlag != "" {
+		db, err := usbids.Load(idsFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", util.Program(), err)
+			os.Exit(1)
+		}
+		usbids.SetDefault(db)
+	} else if db, err := usbids.LoadSystem(); err == nil {
+		usbids.SetDefault(db)
+	}
+
 	vid, pid := uint16(vidFlag), uint16(pidFlag)
 	hid.Enumerate(vid, pid, func(info *hid.DeviceInfo) error {
+		mfr, product := names(info)
 		fmt.Printf("%s: ID %04x:%04x %s %s\n",
-			info.Path, info.VendorID, info.ProductID, info.MfrStr, info.ProductStr)
+			info.Path, info.VendorID, info.ProductID, mfr, product)
+		if checkFlag {
+			if d, err := hid.OpenPath(info.Path); err != nil {
+				fmt.Print(indent(hid.Explain(err, info)))
+			} else {
+				d.Close()
+			}
+		}
 		if verboseFlag {
 			fmt.Println("Device Information:")
 			fmt.Printf("\tPath         %s\n", info.Path)
@@ -106,6 +201,10 @@ func main() {
 			fmt.Printf("\tUsage        %#04x\n", info.Usage)
 			fmt.Printf("\tInterfaceNbr %d\n", info.InterfaceNbr)
 			fmt.Printf("\tBusType      %s\n", info.BusType)
+			fmt.Printf("\tPortPath     %s\n", fmtString(info.PortPath))
+			for _, c := range info.Collections {
+				fmt.Printf("\tCollection   %s\n", fmtCollection(c))
+			}
 			fmt.Println()
 		}
 		return nil
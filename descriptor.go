@@ -0,0 +1,309 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// MaxReportDescriptorSize is the maximum size of a report descriptor.
+const MaxReportDescriptorSize = 4096
+
+// ErrMalformedDescriptor is returned if a report descriptor cannot be parsed.
+var ErrMalformedDescriptor = errors.New("malformed report descriptor")
+
+// Collection describes a top-level collection of a HID device.
+type Collection struct {
+	UsagePage uint16 // Usage Page for Collection
+	Usage     uint16 // Usage for Collection
+	ReportIDs []byte // Report IDs Contained in Collection
+}
+
+// Report descriptor item types and tags. See the HID specification, version
+// 1.11, section 6.2.2.
+const (
+	itemTypeMain   = 0
+	itemTypeGlobal = 1
+	itemTypeLocal  = 2
+
+	itemTagInput         = 0x8
+	itemTagOutput        = 0x9
+	itemTagFeature       = 0xb
+	itemTagCollection    = 0xa
+	itemTagEndCollection = 0xc
+
+	itemTagUsagePage   = 0x0
+	itemTagReportSize  = 0x7
+	itemTagReportID    = 0x8
+	itemTagReportCount = 0x9
+	itemTagPush        = 0xa
+	itemTagPop         = 0xb
+
+	itemTagUsage = 0x0
+
+	itemPrefixLong = 0xfe
+)
+
+// descriptorGlobals holds the global items tracked while parsing a report
+// descriptor.
+type descriptorGlobals struct {
+	usagePage   uint16
+	reportID    byte
+	reportSize  uint32
+	reportCount uint32
+}
+
+// parseItems calls fn for each short item in the report descriptor desc with
+// the item type, tag, data, and data size. Long items are skipped. It returns
+// ErrMalformedDescriptor if an item is truncated, or the first error returned
+// by fn.
+func parseItems(desc []byte, fn func(typ, tag byte, data uint32, size int) error) error {
+	for pos := 0; pos < len(desc); {
+		prefix := desc[pos]
+		if prefix == itemPrefixLong {
+			// Long items are reserved and carry no information of
+			// interest; skip over the data (section 6.2.2.3).
+			if pos+2 >= len(desc) {
+				return ErrMalformedDescriptor
+			}
+			size := 3 + int(desc[pos+1])
+			if pos+size > len(desc) {
+				return ErrMalformedDescriptor
+			}
+			pos += size
+			continue
+		}
+
+		size := int(prefix & 0x3)
+		if size == 3 {
+			size = 4
+		}
+		if pos+1+size > len(desc) {
+			return ErrMalformedDescriptor
+		}
+		var data uint32
+		for i := 0; i < size; i++ {
+			data |= uint32(desc[pos+1+i]) << (8 * i)
+		}
+		pos += 1 + size
+		if err := fn((prefix>>2)&0x3, prefix>>4, data, size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseGlobal updates globals and stack for the global item with the given
+// tag and data. It returns ErrMalformedDescriptor if the item is invalid.
+func parseGlobal(globals *descriptorGlobals, stack *[]descriptorGlobals, tag byte, data uint32) error {
+	switch tag {
+	case itemTagUsagePage:
+		globals.usagePage = uint16(data)
+	case itemTagReportSize:
+		globals.reportSize = data
+	case itemTagReportID:
+		if data == 0 || data > 0xff {
+			return ErrMalformedDescriptor
+		}
+		globals.reportID = byte(data)
+	case itemTagReportCount:
+		globals.reportCount = data
+	case itemTagPush:
+		*stack = append(*stack, *globals)
+	case itemTagPop:
+		if len(*stack) == 0 {
+			return ErrMalformedDescriptor
+		}
+		*globals = (*stack)[len(*stack)-1]
+		*stack = (*stack)[:len(*stack)-1]
+	}
+	return nil
+}
+
+// ParseCollections parses the report descriptor desc and returns the
+// top-level collections it describes along with an error, if any. Report IDs
+// are reported for each collection in ascending order; devices which only
+// support a single report do not report any IDs.
+func ParseCollections(desc []byte) ([]Collection, error) {
+	var (
+		collections []Collection
+		globals     descriptorGlobals
+		stack       []descriptorGlobals
+		usage       uint32 // first usage in scope
+		usageFound  bool
+		extended    bool // usage includes the usage page
+		depth       int
+	)
+
+	err := parseItems(desc, func(typ, tag byte, data uint32, size int) error {
+		switch typ {
+		case itemTypeMain:
+			switch tag {
+			case itemTagCollection:
+				if depth == 0 {
+					c := Collection{UsagePage: globals.usagePage}
+					if usageFound {
+						if extended {
+							c.UsagePage = uint16(usage >> 16)
+						}
+						c.Usage = uint16(usage)
+					}
+					collections = append(collections, c)
+				}
+				depth++
+			case itemTagEndCollection:
+				if depth == 0 {
+					return ErrMalformedDescriptor
+				}
+				depth--
+			case itemTagInput, itemTagOutput, itemTagFeature:
+				if depth > 0 && globals.reportID != 0 {
+					c := &collections[len(collections)-1]
+					c.ReportIDs = appendReportID(c.ReportIDs, globals.reportID)
+				}
+			}
+			usageFound = false // local items are consumed by main items
+
+		case itemTypeGlobal:
+			return parseGlobal(&globals, &stack, tag, data)
+
+		case itemTypeLocal:
+			if tag == itemTagUsage && !usageFound {
+				// A collection is identified by the first usage
+				// preceding it (section 6.2.2.6). Usages with a size
+				// of 4 bytes include the usage page in the high-order
+				// word (section 6.2.2.8).
+				usage, usageFound, extended = data, true, size == 4
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, ErrMalformedDescriptor
+	}
+	return collections, nil
+}
+
+// ReportType describes the type of a report.
+type ReportType int
+
+const (
+	InputReport   ReportType = iota + 1 // Input Report
+	OutputReport                        // Output Report
+	FeatureReport                       // Feature Report
+)
+
+// ReportSizes maps report IDs to report sizes in bytes for each report type.
+// Devices which only support a single report use a report ID of 0. Sizes do
+// not include the report ID.
+type ReportSizes map[ReportType]map[byte]int
+
+// Size returns the size in bytes of the report with the given type and ID,
+// and whether the report is described.
+func (s ReportSizes) Size(typ ReportType, id byte) (int, bool) {
+	n, ok := s[typ][id]
+	return n, ok
+}
+
+// Numbered reports whether reports are prefixed by a report ID.
+func (s ReportSizes) Numbered() bool {
+	for _, ids := range s {
+		for id := range ids {
+			if id != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseReportSizes parses the report descriptor desc and returns the size of
+// each report it describes along with an error, if any. ErrMalformedDescriptor
+// is returned if the size of a report exceeds math.MaxUint32 bits.
+func ParseReportSizes(desc []byte) (ReportSizes, error) {
+	var (
+		bits    = make(map[ReportType]map[byte]uint64)
+		globals descriptorGlobals
+		stack   []descriptorGlobals
+	)
+
+	err := parseItems(desc, func(typ, tag byte, data uint32, size int) error {
+		switch typ {
+		case itemTypeMain:
+			var rt ReportType
+			switch tag {
+			case itemTagInput:
+				rt = InputReport
+			case itemTagOutput:
+				rt = OutputReport
+			case itemTagFeature:
+				rt = FeatureReport
+			default:
+				return nil
+			}
+			if bits[rt] == nil {
+				bits[rt] = make(map[byte]uint64)
+			}
+			n := bits[rt][globals.reportID] + uint64(globals.reportSize)*uint64(globals.reportCount)
+			if n > math.MaxUint32 {
+				return ErrMalformedDescriptor
+			}
+			bits[rt][globals.reportID] = n
+
+		case itemTypeGlobal:
+			return parseGlobal(&globals, &stack, tag, data)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make(ReportSizes)
+	for rt, ids := range bits {
+		sizes[rt] = make(map[byte]int)
+		for id, n := range ids {
+			sizes[rt][id] = int((n + 7) / 8)
+		}
+	}
+	return sizes, nil
+}
+
+// appendReportID inserts id into the sorted slice ids unless it is already
+// present.
+func appendReportID(ids []byte, id byte) []byte {
+	i := sort.Search(len(ids), func(i int) bool { return ids[i] >= id })
+	if i < len(ids) && ids[i] == id {
+		return ids
+	}
+	ids = append(ids, 0)
+	copy(ids[i+1:], ids[i:])
+	ids[i] = id
+	return ids
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Report descriptor of a simple keyboard with a single application
+// collection and no report IDs.
+var keyboardDesc = []byte{
+	0x05, 0x01, // Usage Page (Generic Desktop)
+	0x09, 0x06, // Usage (Keyboard)
+	0xa1, 0x01, // Collection (Application)
+	0x05, 0x07, //   Usage Page (Keyboard/Keypad)
+	0x19, 0xe0, //   Usage Minimum (0xe0)
+	0x29, 0xe7, //   Usage Maximum (0xe7)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x25, 0x01, //   Logical Maximum (1)
+	0x75, 0x01, //   Report Size (1)
+	0x95, 0x08, //   Report Count (8)
+	0x81, 0x02, //   Input (Data,Var,Abs)
+	0xc0, //       End Collection
+}
+
+func TestParseCollections(t *testing.T) {
+	tests := []struct {
+		name string
+		desc []byte
+		want []Collection
+	}{
+		{
+			name: "Keyboard",
+			desc: keyboardDesc,
+			want: []Collection{
+				{UsagePage: 0x01, Usage: 0x06},
+			},
+		},
+		{
+			name: "Composite",
+			desc: []byte{
+				0x05, 0x01, // Usage Page (Generic Desktop)
+				0x09, 0x02, // Usage (Mouse)
+				0xa1, 0x01, // Collection (Application)
+				0x09, 0x01, //   Usage (Pointer)
+				0xa1, 0x00, //   Collection (Physical)
+				0x85, 0x02, //     Report ID (2)
+				0x75, 0x08, //     Report Size (8)
+				0x95, 0x02, //     Report Count (2)
+				0x81, 0x06, //     Input (Data,Var,Rel)
+				0xc0,             //         End Collection
+				0xc0,             //       End Collection
+				0x06, 0x00, 0xff, // Usage Page (Vendor Defined 0xFF00)
+				0x09, 0x01, // Usage (0x01)
+				0xa1, 0x01, // Collection (Application)
+				0x85, 0x11, //   Report ID (17)
+				0x95, 0x13, //   Report Count (19)
+				0x09, 0x02, //   Usage (0x02)
+				0xb1, 0x00, //   Feature (Data,Array,Abs)
+				0x85, 0x10, //   Report ID (16)
+				0x95, 0x06, //   Report Count (6)
+				0x09, 0x03, //   Usage (0x03)
+				0x91, 0x00, //   Output (Data,Array,Abs)
+				0x09, 0x04, //   Usage (0x04)
+				0x81, 0x00, //   Input (Data,Array,Abs)
+				0xc0, //       End Collection
+			},
+			want: []Collection{
+				{UsagePage: 0x01, Usage: 0x02, ReportIDs: []byte{2}},
+				{UsagePage: 0xff00, Usage: 0x01, ReportIDs: []byte{16, 17}},
+			},
+		},
+		{
+			name: "ExtendedUsage",
+			desc: []byte{
+				0x05, 0x01, // Usage Page (Generic Desktop)
+				0x0b, 0x01, 0x00, 0x0c, 0x00, // Usage (Consumer Control)
+				0xa1, 0x01, // Collection (Application)
+				0x05, 0x0c, //   Usage Page (Consumer)
+				0xa4,       //         Push
+				0x85, 0x03, //   Report ID (3)
+				0x09, 0xe9, //   Usage (Volume Increment)
+				0x81, 0x02, //   Input (Data,Var,Abs)
+				0xb4,       //         Pop
+				0x09, 0xea, //   Usage (Volume Decrement)
+				0x81, 0x02, //   Input (Data,Var,Abs)
+				0xc0, //       End Collection
+			},
+			want: []Collection{
+				{UsagePage: 0x0c, Usage: 0x01, ReportIDs: []byte{3}},
+			},
+		},
+		{
+			name: "MultipleUsages",
+			desc: []byte{
+				0x05, 0x01, // Usage Page (Generic Desktop)
+				0x09, 0x04, // Usage (Joystick)
+				0x09, 0x05, // Usage (Game Pad)
+				0xa1, 0x01, // Collection (Application)
+				0xc0, //       End Collection
+			},
+			want: []Collection{
+				{UsagePage: 0x01, Usage: 0x04},
+			},
+		},
+		{
+			name: "LongItem",
+			desc: []byte{
+				0x05, 0x01, // Usage Page (Generic Desktop)
+				0x09, 0x06, // Usage (Keyboard)
+				0xfe, 0x02, 0xf0, 0x01, 0x02, // Long Item (0xf0)
+				0xa1, 0x01, // Collection (Application)
+				0xc0, //       End Collection
+			},
+			want: []Collection{
+				{UsagePage: 0x01, Usage: 0x06},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCollections(tt.desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCollectionsMalformed(t *testing.T) {
+	for _, desc := range [][]byte{
+		{0x05},                   // truncated item
+		{0xa1, 0x01},             // missing End Collection
+		{0xc0},                   // unbalanced End Collection
+		{0xb4},                   // Pop without Push
+		{0x85, 0x00},             // invalid Report ID
+		{0xfe},                   // truncated long item
+		{0xfe, 0x02},             // long item missing tag
+		{0xfe, 0x04, 0x00, 0x01}, // long item with truncated data
+		{0xa1, 0x01, 0xc0, 0xc0}, // extra End Collection
+	} {
+		if _, err := ParseCollections(desc); err != ErrMalformedDescriptor {
+			t.Errorf("% x: got %v, want %v", desc, err, ErrMalformedDescriptor)
+		}
+	}
+}
+
+func TestParseReportSizesOverflow(t *testing.T) {
+	desc := []byte{
+		0x77, 0xff, 0xff, 0xff, 0xff, // Report Size (4294967295)
+		0x97, 0x02, 0x00, 0x00, 0x00, // Report Count (2)
+		0x81, 0x02, // Input (Data,Var,Abs)
+	}
+	if _, err := ParseReportSizes(desc); err != ErrMalformedDescriptor {
+		t.Errorf("got %v, want %v", err, ErrMalformedDescriptor)
+	}
+}
+
+func TestParseReportSizes(t *testing.T) {
+	desc := []byte{
+		0x06, 0x00, 0xff, // Usage Page (Vendor Defined 0xFF00)
+		0x09, 0x01, // Usage (0x01)
+		0xa1, 0x01, // Collection (Application)
+		0x85, 0x02, //   Report ID (2)
+		0x75, 0x08, //   Report Size (8)
+		0x95, 0x3f, //   Report Count (63)
+		0x91, 0x02, //   Output (Data,Var,Abs)
+		0x85, 0x03, //   Report ID (3)
+		0x95, 0x10, //   Report Count (16)
+		0x81, 0x02, //   Input (Data,Var,Abs)
+		0x85, 0x05, //   Report ID (5)
+		0x75, 0x01, //   Report Size (1)
+		0x95, 0x0c, //   Report Count (12)
+		0xb1, 0x02, //   Feature (Data,Var,Abs)
+		0xc0, //       End Collection
+	}
+	sizes, err := ParseReportSizes(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ReportSizes{
+		InputReport:   {3: 16},
+		OutputReport:  {2: 63},
+		FeatureReport: {5: 2},
+	}
+	if !reflect.DeepEqual(sizes, want) {
+		t.Errorf("got %v, want %v", sizes, want)
+	}
+	if !sizes.Numbered() {
+		t.Error("expected numbered reports")
+	}
+
+	sizes, err = ParseReportSizes(keyboardDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := sizes.Size(InputReport, 0); !ok || n != 1 {
+		t.Errorf("got %d, want 1", n)
+	}
+	if sizes.Numbered() {
+		t.Error("expected unnumbered reports")
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// sysfsWrite writes data to the sysfs attribute at path. Permission errors
+// are annotated, as the driver control attributes are writable only by root.
+func sysfsWrite(path, data string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err == nil {
+		_, err = f.WriteString(data)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if errors.Is(err, os.ErrPermission) {
+		return fmt.Errorf("%w (root privileges or CAP_DAC_OVERRIDE are required)", err)
+	}
+	return err
+}
+
+// hidDriverPath returns the sysfs directory of the HID driver with the given
+// name and an error if it does not exist.
+func hidDriverPath(driver string) (string, error) {
+	if driver == "" || driver != filepath.Base(driver) {
+		return "", fmt.Errorf("invalid driver name: %q", driver)
+	}
+	dir := sysfsPath("bus", "hid", "drivers", driver)
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("driver %s: %w", driver, err)
+	}
+	return dir, nil
+}
+
+// hidDevicePath returns the sysfs directory of the HID device with the given
+// sysfs name and an error if it does not exist.
+func hidDevicePath(sysname string) (string, error) {
+	if !isHIDDevName(sysname) {
+		return "", fmt.Errorf("invalid HID device name: %q", sysname)
+	}
+	dir := sysfsPath("bus", "hid", "devices", sysname)
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("device %s: %w", sysname, err)
+	}
+	return dir, nil
+}
+
+// LinuxDrivers returns the names of the HID drivers registered with the Linux
+// kernel and an error, if any.
+func LinuxDrivers() ([]string, error) {
+	entries, err := os.ReadDir(sysfsPath("bus", "hid", "drivers"))
+	if err != nil {
+		return nil, err
+	}
+	drivers := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		drivers = append(drivers, entry.Name())
+	}
+	sort.Strings(drivers)
+	return drivers, nil
+}
+
+// LinuxDriver returns the name of the driver bound to the HID device with
+// the given sysfs name (see LinuxDeviceInfo.Sysname) and an error, if any. If
+// no driver is bound, an empty string is returned.
+func LinuxDriver(sysname string) (string, error) {
+	dir, err := hidDevicePath(sysname)
+	if err != nil {
+		return "", err
+	}
+	return sysfsLink(dir, "driver"), nil
+}
+
+// LinuxUnbind unbinds the HID device with the given sysfs name from its
+// driver. If no driver is bound, LinuxUnbind does nothing.
+//
+// Note that the hidraw device node is removed once the driver is unbound;
+// the sysfs name must be used to refer to the device until it is bound again.
+func LinuxUnbind(sysname string) error {
+	driver, err := LinuxDriver(sysname)
+	if err != nil || driver == "" {
+		return err
+	}
+	if err := sysfsWrite(sysfsPath("bus", "hid", "drivers", driver, "unbind"), sysname); err != nil {
+		return fmt.Errorf("unbind %s from %s: %w", sysname, driver, err)
+	}
+	return nil
+}
+
+// LinuxBind binds the HID device with the given sysfs name to the named
+// driver. The device must not be bound to a driver; see LinuxUnbind.
+func LinuxBind(sysname, driver string) error {
+	if _, err := hidDevicePath(sysname); err != nil {
+		return err
+	}
+	dir, err := hidDriverPath(driver)
+	if err != nil {
+		return err
+	}
+	if err := sysfsWrite(filepath.Join(dir, "bind"), sysname); err != nil {
+		return fmt.Errorf("bind %s to %s: %w", sysname, driver, err)
+	}
+	return nil
+}
+
+// writeDriverID writes a dynamic device ID to the attribute name of the
+// named HID driver.
+func writeDriverID(driver, name string, bus BusType, vid, pid uint16) error {
+	dir, err := hidDriverPath(driver)
+	if err != nil {
+		return err
+	}
+	b, err := linuxBus(bus)
+	if err != nil {
+		return err
+	}
+	id := fmt.Sprintf("%04x %04x %04x", b, vid, pid)
+	if err := sysfsWrite(filepath.Join(dir, name), id); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("driver %s does not support %s", driver, name)
+		}
+		return fmt.Errorf("%s %s for %s: %w", name, id, driver, err)
+	}
+	return nil
+}
+
+// LinuxAddDriverID adds a dynamic device ID to the named HID driver using its
+// new_id attribute. The driver will bind to matching devices that are not
+// bound to another driver, overriding its static device table.
+func LinuxAddDriverID(driver string, bus BusType, vid, pid uint16) error {
+	return writeDriverID(driver, "new_id", bus, vid, pid)
+}
+
+// LinuxRemoveDriverID removes a dynamic device ID previously added by
+// LinuxAddDriverID using the remove_id attribute of the named HID driver.
+// Not all kernels provide remove_id for HID drivers; in that case, an error is
+// returned.
+func LinuxRemoveDriverID(driver string, bus BusType, vid, pid uint16) error {
+	return writeDriverID(driver, "remove_id", bus, vid, pid)
+}
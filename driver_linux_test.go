@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testSysname = "0003:046D:C52B.0001"
+
+// readAttr returns the contents of the sysfs attribute at path relative to
+// the root of fs.
+func (fs *fakeSysfs) readAttr(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(fs.root, path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestLinuxDrivers(t *testing.T) {
+	newFakeSysfs(t)
+	drivers, err := LinuxDrivers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"hid-generic", "logitech-djreceiver"}; !reflect.DeepEqual(drivers, want) {
+		t.Errorf("got %v, want %v", drivers, want)
+	}
+
+	driver, err := LinuxDriver(testSysname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if driver != "hid-generic" {
+		t.Errorf("got %s, want hid-generic", driver)
+	}
+	if _, err := LinuxDriver("0003:046D:C52B.0009"); err == nil {
+		t.Error("expected error for missing device")
+	}
+}
+
+func TestLinuxBind(t *testing.T) {
+	fs := newFakeSysfs(t)
+	if err := LinuxUnbind(testSysname); err != nil {
+		t.Fatal(err)
+	}
+	if got := fs.readAttr(t, "bus/hid/drivers/hid-generic/unbind"); got != testSysname {
+		t.Errorf("unbind: got %q, want %q", got, testSysname)
+	}
+
+	if err := LinuxBind(testSysname, "logitech-djreceiver"); err != nil {
+		t.Fatal(err)
+	}
+	if got := fs.readAttr(t, "bus/hid/drivers/logitech-djreceiver/bind"); got != testSysname {
+		t.Errorf("bind: got %q, want %q", got, testSysname)
+	}
+
+	for _, driver := range []string{"hid-multitouch", "../usb", ""} {
+		if err := LinuxBind(testSysname, driver); err == nil {
+			t.Errorf("%q: expected error for invalid driver", driver)
+		}
+	}
+}
+
+func TestLinuxDriverID(t *testing.T) {
+	fs := newFakeSysfs(t)
+	if err := LinuxAddDriverID("logitech-djreceiver", BusUSB, 0x046d, 0xc52b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fs.readAttr(t, "bus/hid/drivers/logitech-djreceiver/new_id"), "0003 046d c52b"; got != want {
+		t.Errorf("new_id: got %q, want %q", got, want)
+	}
+
+	// The fake driver does not provide remove_id.
+	if err := LinuxRemoveDriverID("logitech-djreceiver", BusUSB, 0x046d, 0xc52b); err == nil {
+		t.Error("expected error for missing remove_id")
+	}
+	if err := LinuxAddDriverID("hid-generic", BusUnknown, 0x046d, 0xc52b); err == nil {
+		t.Error("expected error for unknown bus type")
+	}
+}
+
+func TestLinuxBindPermission(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced for root")
+	}
+	fs := newFakeSysfs(t)
+	if err := os.Chmod(filepath.Join(fs.root, "bus/hid/drivers/hid-generic/unbind"), 0444); err != nil {
+		t.Fatal(err)
+	}
+	if err := LinuxUnbind(testSysname); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("got %v, want permission error", err)
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// hidUevent describes the uevent attributes of a HID device.
+type hidUevent struct {
+	busType   uint32
+	vendorID  uint16
+	productID uint16
+	name      string // HID_NAME
+	uniq      string // HID_UNIQ
+}
+
+// parseHIDUevent parses the uevent attributes of the HID device in the sysfs
+// directory dir. It returns false if any attribute is missing.
+func parseHIDUevent(dir string) (*hidUevent, bool) {
+	uevent := sysfsUevent(dir)
+
+	// HID_ID=0003:000005AC:00008242
+	var u hidUevent
+	var vid, pid uint32
+	if _, err := fmt.Sscanf(uevent["HID_ID"], "%x:%x:%x", &u.busType, &vid, &pid); err != nil {
+		return nil, false
+	}
+	u.vendorID, u.productID = uint16(vid), uint16(pid)
+
+	var nameOk, uniqOk bool
+	u.name, nameOk = uevent["HID_NAME"]
+	u.uniq, uniqOk = uevent["HID_UNIQ"]
+	return &u, nameOk && uniqOk
+}
+
+// sysfsEnumerate returns information for each hidraw device with a matching
+// vendor and product ID found by walking sysfs. Devices are returned in the
+// same order and with the same information as the HIDAPI hidraw backend.
+func sysfsEnumerate(vid, pid uint16) ([]*DeviceInfo, error) {
+	matches, err := filepath.Glob(sysfsPath("class", "hidraw", "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Devices are sorted by syspath, similar to udev_enumerate.
+	var syspaths []string
+	for _, match := range matches {
+		if syspath, err := filepath.EvalSymlinks(match); err == nil {
+			syspaths = append(syspaths, syspath)
+		}
+	}
+	sort.Strings(syspaths)
+
+	var devs []*DeviceInfo
+	for _, syspath := range syspaths {
+		if vid != VendorIDAny || pid != ProductIDAny {
+			u, _ := parseHIDUevent(filepath.Join(syspath, "device"))
+			if u == nil || (vid != VendorIDAny && vid != u.vendorID) ||
+				(pid != ProductIDAny && pid != u.productID) {
+				continue
+			}
+		}
+		devs = append(devs, sysfsDeviceInfo(syspath)...)
+	}
+	if len(devs) == 0 {
+		if vid == VendorIDAny && pid == ProductIDAny {
+			return nil, errors.New("No HID devices found in the system.")
+		}
+		return nil, errors.New("No HID devices with requested VID/PID found in the system.")
+	}
+	return devs, nil
+}
+
+// sysfsDeviceInfo returns information for the hidraw device with the given
+// syspath. An entry is returned for each usage pair found in the report
+// descriptor.
+func sysfsDeviceInfo(syspath string) []*DeviceInfo {
+	hidDev := sysfsParent(syspath, "hid", "")
+	if hidDev == "" {
+		return nil
+	}
+	u, ok := parseHIDUevent(hidDev)
+	if !ok {
+		return nil
+	}
+
+	switch u.busType {
+	case busUSB, busBluetooth, busI2C, busSPI:
+	default:
+		return nil
+	}
+
+	info := &DeviceInfo{
+		Path:         "/dev/" + filepath.Base(syspath),
+		VendorID:     u.vendorID,
+		ProductID:    u.productID,
+		SerialNbr:    u.uniq,
+		InterfaceNbr: -1,
+	}
+	if devname := sysfsUevent(syspath)["DEVNAME"]; devname != "" {
+		info.Path = filepath.Join("/dev", devname)
+	}
+
+	switch u.busType {
+	case busUSB:
+		// Virtual devices created using uhid do not have a parent USB
+		// device; as such, no USB information is available.
+		usbDev := sysfsParent(syspath, "usb", "usb_device")
+		if usbDev == "" {
+			info.ProductStr = u.name
+			break
+		}
+		info.MfrStr = sysfsAttr(usbDev, "manufacturer")
+		info.ProductStr = sysfsAttr(usbDev, "product")
+		info.BusType = BusUSB
+		if n, err := strconv.ParseUint(sysfsAttr(usbDev, "bcdDevice"), 16, 16); err == nil {
+			info.ReleaseNbr = uint16(n)
+		}
+		if intf := sysfsParent(syspath, "usb", "usb_interface"); intf != "" {
+			if n, err := strconv.ParseInt(sysfsAttr(intf, "bInterfaceNumber"), 16, 0); err == nil {
+				info.InterfaceNbr = int(n)
+			}
+		}
+	case busBluetooth:
+		info.ProductStr = u.name
+		info.BusType = BusBluetooth
+	case busI2C:
+		info.ProductStr = u.name
+		info.BusType = BusI2C
+	case busSPI:
+		info.ProductStr = u.name
+		info.BusType = BusSPI
+	}
+
+	desc, err := os.ReadFile(filepath.Join(syspath, "device", "report_descriptor"))
+	if err != nil {
+		return []*DeviceInfo{info}
+	}
+	pairs := usagePairs(desc)
+	if len(pairs) == 0 {
+		return []*DeviceInfo{info}
+	}
+
+	infos := make([]*DeviceInfo, len(pairs))
+	for i, pair := range pairs {
+		c := *info
+		c.UsagePage, c.Usage = pair[0], pair[1]
+		infos[i] = &c
+	}
+	return infos
+}
+
+// hidItemSize returns the data length and key size of the report descriptor
+// item at pos. It returns false if the item is malformed.
+func hidItemSize(desc []byte, pos int) (dataLen, keySize int, ok bool) {
+	key := desc[pos]
+
+	// Long items store the length of the data in the next byte. See the
+	// HID specification, version 1.11, section 6.2.2.3.
+	if key&0xf0 == 0xf0 && pos+1 < len(desc) {
+		return int(desc[pos+1]), 3, true
+	}
+
+	// Short items store the size code in the bottom two bits of the key.
+	// See the HID specification, version 1.11, section 6.2.2.2.
+	switch sizeCode := key & 0x3; sizeCode {
+	case 3:
+		return 4, 1, true
+	default:
+		return int(sizeCode), 1, true
+	}
+}
+
+// hidItemData returns numBytes bytes of data from the report descriptor item
+// at cur. Zero is returned if there are not enough bytes.
+func hidItemData(desc []byte, numBytes, cur int) uint32 {
+	if cur+numBytes >= len(desc) {
+		return 0
+	}
+	switch numBytes {
+	case 1:
+		return uint32(desc[cur+1])
+	case 2:
+		return uint32(desc[cur+2])<<8 | uint32(desc[cur+1])
+	case 4:
+		return uint32(desc[cur+4])<<24 | uint32(desc[cur+3])<<16 |
+			uint32(desc[cur+2])<<8 | uint32(desc[cur+1])
+	}
+	return 0
+}
+
+// skipCollection returns the position of the End Collection item matching
+// the Collection item at pos along with its data length and key size. It
+// returns false if the end of the collection is not found.
+func skipCollection(desc []byte, pos int) (int, int, int, bool) {
+	level := 0
+	for pos < len(desc) {
+		dataLen, keySize, _ := hidItemSize(desc, pos)
+		switch desc[pos] & 0xfc {
+		case 0xa0: // Collection 6.2.2.4 (Main)
+			level++
+		case 0xc0: // End Collection 6.2.2.4 (Main)
+			level--
+		}
+		if level < 0 {
+			return 0, 0, 0, false
+		}
+		if level == 0 {
+			return pos, dataLen, keySize, true
+		}
+		pos += dataLen + keySize
+	}
+	return 0, 0, 0, false
+}
+
+// usagePairs returns the usage page and usage pairs of the report descriptor
+// desc. A pair is returned for each Collection with a usage in scope; if no
+// top-level collection is defined, the first pair found is returned. This
+// mirrors the algorithm used by the HIDAPI hidraw backend, which gives similar
+// results to kIOHIDDeviceUsagePairsKey on macOS.
+func usagePairs(desc []byte) [][2]uint16 {
+	var (
+		pairs          [][2]uint16
+		pos            int
+		usagePage      uint16
+		usagePageFound bool
+	)
+
+	for initial := true; ; initial = false {
+		var usage uint16
+		usageFound, pairFound := false, false
+
+	loop:
+		for pos < len(desc) {
+			dataLen, keySize, _ := hidItemSize(desc, pos)
+
+			switch desc[pos] & 0xfc {
+			case 0x4: // Usage Page 6.2.2.7 (Global)
+				usagePage = uint16(hidItemData(desc, dataLen, pos))
+				usagePageFound = true
+
+			case 0x8: // Usage 6.2.2.8 (Local)
+				if dataLen == 4 { // Usages 5.5 / Usage Page 6.2.2.7
+					usagePage = uint16(hidItemData(desc, 2, pos+2))
+					usagePageFound = true
+					usage = uint16(hidItemData(desc, 2, pos))
+				} else {
+					usage = uint16(hidItemData(desc, dataLen, pos))
+				}
+				usageFound = true
+
+			case 0xa0: // Collection 6.2.2.4 (Main)
+				var ok bool
+				if pos, dataLen, keySize, ok = skipCollection(desc, pos); !ok {
+					return pairs // malformed report
+				}
+
+				// A pair is valid - to be reported when Collection is found
+				if usageFound && usagePageFound {
+					pairFound = true
+					break loop
+				}
+			}
+
+			// Skip over this key and its associated data
+			pos += dataLen + keySize
+		}
+
+		// If no top-level application collection is found and usage
+		// page/usage pair is found, pair is valid. See
+		// https://docs.microsoft.com/en-us/windows-hardware/drivers/hid/top-level-collections
+		if pairFound || (initial && usageFound && usagePageFound) {
+			pairs = append(pairs, [2]uint16{usagePage, usage})
+		}
+		if !pairFound {
+			return pairs
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSysfsEnumerate(t *testing.T) {
+	newFakeSysfs(t)
+
+	devs, err := sysfsEnumerate(VendorIDAny, ProductIDAny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*DeviceInfo{{
+		Path:         "/dev/hidraw3",
+		VendorID:     0x046d,
+		ProductID:    0xc52b,
+		ReleaseNbr:   0x1211,
+		MfrStr:       "Logitech",
+		ProductStr:   "USB Receiver",
+		UsagePage:    0x01,
+		Usage:        0x06,
+		InterfaceNbr: 0,
+		BusType:      BusUSB,
+	}}
+	if !reflect.DeepEqual(devs, want) {
+		t.Errorf("got %+v, want %+v", devs[0], want[0])
+	}
+
+	if _, err := sysfsEnumerate(0x046d, 0xffff); err == nil {
+		t.Error("expected error for unmatched VID/PID")
+	}
+}
+
+func TestUsagePairs(t *testing.T) {
+	tests := []struct {
+		name string
+		desc []byte
+		want [][2]uint16
+	}{
+		{
+			name: "Keyboard",
+			desc: keyboardDesc,
+			want: [][2]uint16{{0x01, 0x06}},
+		},
+		{
+			name: "Multiple",
+			desc: []byte{
+				0x05, 0x01, // Usage Page (Generic Desktop)
+				0x09, 0x02, // Usage (Mouse)
+				0xa1, 0x01, // Collection (Application)
+				0xc0,             // End Collection
+				0x06, 0x00, 0xff, // Usage Page (Vendor Defined 0xFF00)
+				0x09, 0x01, // Usage (0x01)
+				0xa1, 0x01, // Collection (Application)
+				0xc0, // End Collection
+			},
+			want: [][2]uint16{{0x01, 0x02}, {0xff00, 0x01}},
+		},
+		{
+			name: "Empty",
+			desc: nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := usagePairs(tt.desc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
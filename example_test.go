@@ -26,6 +26,7 @@ package hid_test
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/sstallion/go-hid"
 )
@@ -117,3 +118,31 @@ func ExampleEnumerate() {
 		return nil
 	})
 }
+
+// The following example demonstrates use of the Snapshot and Diff functions
+// to verify that the expected devices are attached to the system.
+func ExampleDiff() {
+	// Load the expected devices, for example from a file created by
+	// marshaling an earlier snapshot.
+	expected := hid.NewInventory(time.Now(), []hid.DeviceInfo{
+		{
+			VendorID:     0x4d8,
+			ProductID:    0x3f,
+			SerialNbr:    "0001",
+			UsagePage:    0xff00,
+			Usage:        0x01,
+			InterfaceNbr: 0,
+			BusType:      hid.BusUSB,
+		},
+	})
+
+	actual, err := hid.Snapshot()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	delta := hid.Diff(expected, actual)
+	for _, info := range delta.Removed {
+		fmt.Printf("missing: %s\n", info.ID())
+	}
+}
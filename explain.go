@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// Explain returns a diagnostic describing why the HID device described by
+// info could not be opened, along with advice to resolve the problem. err is
+// the error returned when opening the device. If err is nil, an empty string
+// is returned.
+//
+// On Linux, permission errors are diagnosed by inspecting the owner, group,
+// mode, and access control list of the device node (hidraw or usbfs) and the
+// credentials of the process. A udev rule granting access is suggested.
+func Explain(err error, info *DeviceInfo) string {
+	if err == nil {
+		return ""
+	}
+	return sysExplain(err, info)
+}
+
+// isPermissionError reports whether err indicates that access to a device
+// was denied. HIDAPI reports errors as strings, so the error message is
+// inspected if err does not wrap os.ErrPermission.
+func isPermissionError(err error) bool {
+	if errors.Is(err, os.ErrPermission) {
+		return true
+	}
+	s := strings.ToLower(err.Error())
+	for _, substr := range []string{"permission denied", "access denied", "operation not permitted"} {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// POSIX ACL entry tags defined in linux/posix_acl.h.
+const (
+	aclUser     = 0x02
+	aclGroupObj = 0x04
+	aclGroup    = 0x08
+	aclMask     = 0x10
+)
+
+// aclEntry describes an entry of a POSIX access control list.
+type aclEntry struct {
+	tag  uint16
+	perm uint16
+	id   uint32
+}
+
+// readACL returns the owning group and named user and group entries of the
+// access control list of the file at path along with the mask entry
+// permissions. If the file has no access control list, no entries are
+// returned.
+func readACL(path string) (entries []aclEntry, mask uint16) {
+	buf := make([]byte, 1024)
+	n, err := unix.Getxattr(path, "system.posix_acl_access", buf)
+	if err != nil || n < 4 || binary.LittleEndian.Uint32(buf) != 2 {
+		return nil, 0
+	}
+	mask = 07
+	for b := buf[4:n]; len(b) >= 8; b = b[8:] {
+		e := aclEntry{
+			tag:  binary.LittleEndian.Uint16(b),
+			perm: binary.LittleEndian.Uint16(b[2:]),
+			id:   binary.LittleEndian.Uint32(b[4:]),
+		}
+		switch e.tag {
+		case aclUser, aclGroupObj, aclGroup:
+			entries = append(entries, e)
+		case aclMask:
+			mask = e.perm
+		}
+	}
+	return entries, mask
+}
+
+// permits reports whether a process with the effective user ID uid and the
+// given groups may read and write a file with the owner, group, and mode
+// given by st and the access control list entries and mask returned by
+// readACL. As with the kernel, the first class of entries matching the
+// process (owner, named users, groups, then other) determines access. If an
+// access control list is present, the group class bits of the mode are the
+// mask and the permissions of the owning group are given by its entry.
+func permits(uid uint32, groups map[uint32]bool, st *unix.Stat_t, entries []aclEntry, mask uint16) bool {
+	const rw = 06
+	mode := uint16(st.Mode & 0777)
+	switch {
+	case uid == 0:
+		return true
+	case st.Uid == uid:
+		return mode>>6&rw == rw
+	}
+	if len(entries) == 0 {
+		if groups[st.Gid] {
+			return mode>>3&rw == rw
+		}
+		return mode&rw == rw
+	}
+
+	for _, e := range entries {
+		if e.tag == aclUser && e.id == uid {
+			return e.perm&mask&rw == rw
+		}
+	}
+	matched := false
+	for _, e := range entries {
+		if (e.tag == aclGroupObj && groups[st.Gid]) || (e.tag == aclGroup && groups[e.id]) {
+			if e.perm&mask&rw == rw {
+				return true
+			}
+			matched = true
+		}
+	}
+	if matched {
+		return false
+	}
+	return mode&rw == rw
+}
+
+func userName(uid uint32) string {
+	id := strconv.FormatUint(uint64(uid), 10)
+	if u, err := user.LookupId(id); err == nil {
+		return fmt.Sprintf("%s (%s)", id, u.Username)
+	}
+	return id
+}
+
+func groupName(gid uint32) string {
+	id := strconv.FormatUint(uint64(gid), 10)
+	if g, err := user.LookupGroupId(id); err == nil {
+		return fmt.Sprintf("%s (%s)", id, g.Name)
+	}
+	return id
+}
+
+// deviceNode returns the device node opened for the device described by info
+// and whether it is a usbfs device node used by the libusb backend.
+func deviceNode(info *DeviceInfo) (node string, usbfs bool) {
+	if filepath.IsAbs(info.Path) {
+		return info.Path, false
+	}
+	// libusb paths name the USB interface (bus-port.port:config.interface).
+	port, _, _ := strings.Cut(info.Path, ":")
+	dir := sysfsPath("bus", "usb", "devices", port)
+	busnum, err1 := strconv.Atoi(sysfsAttr(dir, "busnum"))
+	devnum, err2 := strconv.Atoi(sysfsAttr(dir, "devnum"))
+	if err1 != nil || err2 != nil {
+		return "", true
+	}
+	return fmt.Sprintf("/dev/bus/usb/%03d/%03d", busnum, devnum), true
+}
+
+func sysExplain(err error, info *DeviceInfo) string {
+	if !isPermissionError(err) {
+		if errors.Is(err, unix.EBUSY) {
+			return fmt.Sprintf("%v\nThe device is in use by another process or kernel driver.", err)
+		}
+		return err.Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintln(&b, err)
+
+	node, usbfs := deviceNode(info)
+	kind := "hidraw"
+	if usbfs {
+		kind = "usbfs"
+	}
+	var st unix.Stat_t
+	if node == "" || unix.Stat(node, &st) != nil {
+		fmt.Fprintf(&b, "The %s device node for %s could not be found; the device may have been disconnected.\n", kind, info.Path)
+		return strings.TrimRight(b.String(), "\n")
+	}
+	mode := st.Mode & 0777
+	fmt.Fprintf(&b, "Device node %s (%s) is owned by user %s and group %s with mode %04o.\n",
+		node, kind, userName(st.Uid), groupName(st.Gid), mode)
+
+	uid, gid := uint32(os.Geteuid()), uint32(os.Getegid())
+	groups := map[uint32]bool{gid: true}
+	names := []string{groupName(gid)}
+	if gids, err := os.Getgroups(); err == nil {
+		for _, g := range gids {
+			if !groups[uint32(g)] {
+				groups[uint32(g)] = true
+				names = append(names, groupName(uint32(g)))
+			}
+		}
+	}
+	fmt.Fprintf(&b, "The process is running as user %s with groups %s.\n", userName(uid), strings.Join(names, ", "))
+
+	const rw = 06
+	entries, mask := readACL(node)
+	access := permits(uid, groups, &st, entries, mask)
+
+	groupPerm := uint16(mode >> 3)
+	var aclUsers []string
+	for _, e := range entries {
+		switch e.tag {
+		case aclUser:
+			if e.perm&mask&rw == rw {
+				aclUsers = append(aclUsers, userName(e.id))
+			}
+		case aclGroupObj:
+			groupPerm = e.perm & mask
+		}
+	}
+	switch {
+	case len(aclUsers) > 0:
+		fmt.Fprintf(&b, "An access control list grants access to user %s; seat access (uaccess) is granted to the user of the active local session.\n",
+			strings.Join(aclUsers, ", "))
+	default:
+		fmt.Fprintln(&b, "No access control list grants access to the device; seat access (uaccess) is not in effect.")
+	}
+
+	if access {
+		fmt.Fprintln(&b, "The device node permits access; access may be denied by a security module (SELinux, AppArmor) or a container device cgroup.")
+		return strings.TrimRight(b.String(), "\n")
+	}
+
+	if st.Gid != 0 && groupPerm&rw == rw {
+		if u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10)); err == nil {
+			if g, err := user.LookupGroupId(strconv.FormatUint(uint64(st.Gid), 10)); err == nil {
+				fmt.Fprintf(&b, "\nTo grant access, add the user to group %s and log in again:\n\n    sudo usermod -aG %s %s\n",
+					g.Name, g.Name, u.Username)
+			}
+		}
+	}
+	opts := &UdevOptions{Mode: 0660, Uaccess: true}
+	rules, err := udevRule(info, usbfs, opts)
+	if err != nil {
+		// The serial number cannot be written to a rule; match
+		// devices by vendor and product ID only.
+		match := *info
+		match.SerialNbr = ""
+		rules, _ = udevRule(&match, usbfs, opts)
+	}
+	fmt.Fprintf(&b, "\nTo grant access, create /etc/udev/rules.d/70-hid-%04x-%04x.rules containing:\n\n    %s\n",
+		info.VendorID, info.ProductID, rules[0])
+	fmt.Fprintf(&b, "\nthen reload the rules and reconnect the device:\n\n    sudo udevadm control --reload-rules && sudo udevadm trigger\n")
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestExplain(t *testing.T) {
+	node := filepath.Join(t.TempDir(), "hidraw3")
+	if err := os.WriteFile(node, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	info := &DeviceInfo{Path: node, VendorID: 0x046d, ProductID: 0xc52b, BusType: BusUSB}
+	err := &os.PathError{Op: "open", Path: node, Err: unix.EACCES}
+
+	s := Explain(err, info)
+	for _, substr := range []string{err.Error(), node + " (hidraw)", "mode 0000"} {
+		if !strings.Contains(s, substr) {
+			t.Errorf("missing %q in:\n%s", substr, s)
+		}
+	}
+	if os.Geteuid() != 0 {
+		rules, rerr := udevRule(info, false, &UdevOptions{Mode: 0660, Uaccess: true})
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		if !strings.Contains(s, rules[0]) {
+			t.Errorf("missing udev rule %q in:\n%s", rules[0], s)
+		}
+
+		hostile := *info
+		hostile.SerialNbr = `ABC" RUN+="/bin/true`
+		if s := Explain(err, &hostile); strings.Contains(s, "RUN+=") || !strings.Contains(s, rules[0]) {
+			t.Errorf("unexpected udev rule for serial %q in:\n%s", hostile.SerialNbr, s)
+		}
+	}
+
+	if s := Explain(errors.New("hid_open_path: device not found"), info); s != "hid_open_path: device not found" {
+		t.Errorf("unexpected explanation for unrelated error: %s", s)
+	}
+	if s := Explain(nil, info); s != "" {
+		t.Errorf("unexpected explanation for nil error: %s", s)
+	}
+}
+
+func TestPermits(t *testing.T) {
+	const uid, gid = 1000, 100
+	groups := map[uint32]bool{gid: true}
+	tests := []struct {
+		name    string
+		uid     uint32
+		gid     uint32
+		mode    uint32
+		entries []aclEntry
+		mask    uint16
+		want    bool
+	}{
+		{"Owner", uid, 0, 0600, nil, 0, true},
+		{"OwnerDenied", uid, 0, 0066, nil, 0, false},
+		{"Group", 0, gid, 0060, nil, 0, true},
+		{"GroupDenied", 0, gid, 0606, nil, 0, false},
+		{"Other", 0, 0, 0006, nil, 0, true},
+		{"NamedUser", 0, 0, 0660, []aclEntry{{aclUser, 06, uid}, {aclGroupObj, 06, 0}}, 06, true},
+		{"NamedUserMasked", 0, 0, 0606, []aclEntry{{aclUser, 06, uid}, {aclGroupObj, 06, 0}}, 0, false},
+		{"NamedGroup", 0, 0, 0660, []aclEntry{{aclGroupObj, 0, 0}, {aclGroup, 06, gid}}, 06, true},
+		{"GroupObjDenied", 0, gid, 0666, []aclEntry{{aclGroupObj, 0, 0}, {aclUser, 06, 0}}, 06, false},
+		{"OtherWithACL", 0, 0, 0666, []aclEntry{{aclGroupObj, 0, 0}, {aclUser, 06, 0}}, 06, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &unix.Stat_t{Uid: tt.uid, Gid: tt.gid, Mode: unix.S_IFCHR | tt.mode}
+			if got := permits(uid, groups, st, tt.entries, tt.mask); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if !permits(0, nil, &unix.Stat_t{Uid: uid}, nil, 0) {
+		t.Error("expected access for root")
+	}
+}
+
+func TestDeviceNode(t *testing.T) {
+	newFakeSysfs(t)
+	tests := []struct {
+		path  string
+		node  string
+		usbfs bool
+	}{
+		{"/dev/hidraw3", "/dev/hidraw3", false},
+		{"1-2.4:1.0", "/dev/bus/usb/001/005", true},
+	}
+	for _, tt := range tests {
+		node, usbfs := deviceNode(&DeviceInfo{Path: tt.path})
+		if node != tt.node || usbfs != tt.usbfs {
+			t.Errorf("%s: got %s, %v; want %s, %v", tt.path, node, usbfs, tt.node, tt.usbfs)
+		}
+	}
+}
@@ -26,28 +26,24 @@
 // and Windows.
 //
 // See https://github.com/libusb/hidapi for details.
+//
+// On Linux, a pure Go implementation of the hidraw backend is also provided,
+// which does not require cgo. It is selected automatically if cgo is disabled
+// (CGO_ENABLED=0) or explicitly by specifying the purego build constraint.
+//
+// With the exception of the libusb backend and programs built with the
+// systemhidapi build constraint, which use the system HIDAPI library for the
+// hidraw backend, device I/O on Linux is performed in Go: hidraw devices are
+// registered with the runtime network poller, so a blocked Read parks only the
+// calling goroutine rather than an OS thread. Closing a Device unblocks any
+// pending Read.
 package hid
 
-/*
-#cgo darwin LDFLAGS: -framework IOKit -framework CoreFoundation
-#cgo freebsd CFLAGS: -I/usr/local/include
-#cgo freebsd LDFLAGS: -L/usr/local/lib -lusb -liconv -pthread
-#cgo linux LDFLAGS: -ludev -lrt
-#cgo linux,libusb pkg-config: libusb-1.0
-#cgo linux,libusb LDFLAGS: -lpthread
-
-#include <stdint.h>
-#include <stdlib.h>
-#include "hidapi.h"
-*/
-import "C"
-
 import (
 	"errors"
-	"io"
-	"math"
+	"fmt"
+	"sync"
 	"time"
-	"unsafe"
 )
 
 // VendorIDAny and ProductIDAny can be passed to the Enumerate function to
@@ -57,35 +53,19 @@ const (
 	ProductIDAny = 0
 )
 
-// maxStrLen is the maximum length of a string descriptor (bLength).
-const maxStrLen = math.MaxUint8
-
 // ErrTimeout is returned if a blocking operation times out before completing.
 var ErrTimeout = errors.New("timeout")
 
-func wrapErr(err error) error {
-	if err == nil {
-		return errors.New("unspecified error")
-	}
-	return err
-}
-
 // Init initializes the hid package. Calling this function is not strictly
 // necessary, however it is recommended for concurrent programs.
 func Init() error {
-	if res := C.hid_init(); res == -1 {
-		return wrapErr(Error())
-	}
-	return nil
+	return sysInit()
 }
 
 // Exit finalizes the hid package. This function should be called after all
 // device handles have been closed to avoid memory leaks.
 func Exit() error {
-	if res := C.hid_exit(); res == -1 {
-		return wrapErr(Error())
-	}
-	return nil
+	return sysExit()
 }
 
 // BusType describes the underlying bus type.
@@ -113,22 +93,13 @@ type DeviceInfo struct {
 	Usage        uint16  // Usage for Device/Interface
 	InterfaceNbr int     // USB Interface Number
 	BusType      BusType // Underlying Bus Type
-}
+	PortPath     string  // USB Bus and Port Path (see Device.PortPath)
 
-func newDeviceInfo(p *C.struct_hid_device_info) *DeviceInfo {
-	return &DeviceInfo{
-		Path:         C.GoString(p.path),
-		VendorID:     uint16(p.vendor_id),
-		ProductID:    uint16(p.product_id),
-		SerialNbr:    wcstogo(p.serial_number),
-		ReleaseNbr:   uint16(p.release_number),
-		MfrStr:       wcstogo(p.manufacturer_string),
-		ProductStr:   wcstogo(p.product_string),
-		UsagePage:    uint16(p.usage_page),
-		Usage:        uint16(p.usage),
-		InterfaceNbr: int(p.interface_number),
-		BusType:      BusType(p.bus_type),
-	}
+	// Collections describes the top-level collections parsed from the
+	// report descriptor. On Linux, collections are available from
+	// Enumerate; on other platforms, collections are only available from
+	// an open device by calling GetDeviceInfo.
+	Collections []Collection
 }
 
 // EnumFunc is the type of the function called for each HID device attached to
@@ -140,60 +111,103 @@ type EnumFunc func(info *DeviceInfo) error
 // vendor and product ID. To match multiple devices, VendorIDAny and
 // ProductIDAny can be passed to this function. If an error is returned by
 // EnumFunc, Enumerate will return immediately with the original error.
+//
+// Devices are visited using the backends selected by SetBackends.
 func Enumerate(vid, pid uint16, enumFn EnumFunc) error {
-	p := C.hid_enumerate(C.uint16_t(vid), C.uint16_t(pid))
-	defer C.hid_free_enumeration(p)
+	return enumerate(selectedBackends(), vid, pid, enumFn)
+}
 
-	for p != nil {
-		if err := enumFn(newDeviceInfo(p)); err != nil {
-			return err
+func enumerate(backends []Backend, vid, pid uint16, enumFn EnumFunc) error {
+	// Backends return an entry for each usage pair; collections are parsed
+	// once for each device path.
+	collections := make(map[string][]Collection)
+	for _, b := range backends {
+		for _, info := range sysEnumerate(b, vid, pid) {
+			c, ok := collections[info.Path]
+			if !ok {
+				if desc, err := readReportDescriptor(info.Path); err == nil {
+					c, _ = ParseCollections(desc)
+				}
+				collections[info.Path] = c
+			}
+			info.Collections = c
+			info.PortPath = portPath(info.Path)
+			if err := enumFn(info); err != nil {
+				return err
+			}
 		}
-		p = p.next
 	}
 	return nil
 }
 
+// device is implemented by each backend.
+type device interface {
+	write(p []byte) (int, error)
+	readTimeout(p []byte, timeout time.Duration) (int, error)
+	read(p []byte) (int, error)
+	readTimestamped(p []byte) (int, time.Time, error)
+	setNonblock(nonblocking bool) error
+	sendFeatureReport(p []byte) (int, error)
+	getFeatureReport(p []byte) (int, error)
+	getInputReport(p []byte) (int, error)
+	sendOutputReport(p []byte) (int, error)
+	close() error
+	getMfrStr() (string, error)
+	getProductStr() (string, error)
+	getSerialNbr() (string, error)
+	getDeviceInfo() (*DeviceInfo, error)
+	getIndexedStr(index int) (string, error)
+	getReportDescriptor(p []byte) (int, error)
+	lastError() error
+	lastReadError() error
+}
+
 // Device is a HID device attached to the system.
 type Device struct {
-	handle *C.hid_device
+	device
+
+	collOnce    sync.Once
+	collections []Collection
 }
 
 // Open opens a HID device attached to the system with a matching vendor ID,
 // product ID, and serial number. It returns an open device handle and an
 // error, if any.
 func Open(vid, pid uint16, serial string) (*Device, error) {
-	wcs := gotowcs(serial)
-	defer C.free(unsafe.Pointer(wcs))
-
-	handle := C.hid_open(C.uint16_t(vid), C.uint16_t(pid), wcs)
-	if handle == nil {
-		return nil, wrapErr(Error())
-	}
-	return &Device{handle}, nil
+	return open(vid, pid, &serial)
 }
 
 // OpenFirst opens the first HID device attached to the system with a matching
 // vendor ID, and product ID. It returns an open device handle and an error,
 // if any.
 func OpenFirst(vid, pid uint16) (*Device, error) {
-	handle := C.hid_open(C.uint16_t(vid), C.uint16_t(pid), nil)
-	if handle == nil {
-		return nil, wrapErr(Error())
+	return open(vid, pid, nil)
+}
+
+func open(vid, pid uint16, serial *string) (d *Device, err error) {
+	start := time.Now()
+	for _, b := range selectedBackends() {
+		if d, err = sysOpen(b, vid, pid, serial); err == nil {
+			break
+		}
 	}
-	return &Device{handle}, nil
+	return traceOpen(start, "", vid, pid, serial, d, err)
 }
 
 // OpenPath opens the HID device attached to the system with the given path.
 // It returns an open device handle and an error, if any.
+//
+// The path may be prefixed by the name of a backend and a colon to select the
+// backend used to open the device, for example libusb:1-2:1.0. Otherwise, the
+// backend is inferred from the path.
 func OpenPath(path string) (*Device, error) {
-	cs := C.CString(path)
-	defer C.free(unsafe.Pointer(cs))
-
-	handle := C.hid_open_path(cs)
-	if handle == nil {
-		return nil, wrapErr(Error())
+	start := time.Now()
+	b, sysPath := splitPath(path)
+	if !hasBackend(b) {
+		return traceOpen(start, path, 0, 0, nil, nil, fmt.Errorf("%s backend not available", b))
 	}
-	return &Device{handle}, nil
+	d, err := sysOpenPath(b, sysPath)
+	return traceOpen(start, path, 0, 0, nil, d, err)
 }
 
 // Write sends an output report with len(p) bytes to the Device. It returns
@@ -203,14 +217,7 @@ func OpenPath(path string) (*Device, error) {
 // which only support a single report. Data will be sent over the first OUT
 // endpoint if it exists, otherwise the control endpoint will be used.
 func (d *Device) Write(p []byte) (int, error) {
-	data := (*C.uchar)(&p[0])
-	length := C.size_t(len(p))
-
-	res := C.hid_write(d.handle, data, length)
-	if res == -1 {
-		return int(res), wrapErr(d.Error())
-	}
-	return int(res), nil
+	return d.write(p)
 }
 
 // ReadWithTimeout receives an input report with len(p) bytes from the Device
@@ -221,18 +228,7 @@ func (d *Device) Write(p []byte) (int, error) {
 // If the device supports multiple reports, the first byte will contain the
 // report ID.
 func (d *Device) ReadWithTimeout(p []byte, timeout time.Duration) (int, error) {
-	data := (*C.uchar)(&p[0])
-	length := C.size_t(len(p))
-	milliseconds := C.int(timeout / time.Millisecond)
-
-	res := C.hid_read_timeout(d.handle, data, length, milliseconds)
-	switch res {
-	case -1:
-		return int(res), wrapErr(d.ReadError())
-	case 0:
-		return int(res), ErrTimeout
-	}
-	return int(res), nil
+	return d.readTimeout(p, timeout)
 }
 
 // Read receives an input report with len(p) bytes from the Device. It returns
@@ -242,33 +238,28 @@ func (d *Device) ReadWithTimeout(p []byte, timeout time.Duration) (int, error) {
 // If the device supports multiple reports, the first byte will contain the
 // report ID.
 func (d *Device) Read(p []byte) (int, error) {
-	data := (*C.uchar)(&p[0])
-	length := C.size_t(len(p))
-
-	res := C.hid_read(d.handle, data, length)
-	switch res {
-	case -1:
-		return int(res), wrapErr(d.ReadError())
-	case 0:
-		return int(res), ErrTimeout
-	}
-	return int(res), nil
+	return d.read(p)
+}
+
+// ReadTimestamped receives an input report like Read and returns the time the
+// report was received by the backend. The time includes a monotonic clock
+// reading, so it may be compared with other times to measure latency without
+// the scheduling and cgo delays incurred after the report was received.
+//
+// The libusb backend records the time each report is received by its
+// transfer callback, and the hidraw backend the time the read from the device
+// completed. Other backends, and the libusb backend when linked against the
+// system HIDAPI library, record the time the read returned. If no report is
+// read, the zero time is returned.
+func (d *Device) ReadTimestamped(p []byte) (int, time.Time, error) {
+	return d.readTimestamped(p)
 }
 
 // SetNonblock changes the default behavior for Read. If nonblocking is true,
 // Read will return immediately with ErrTimeout if data is not available to be
 // read from the Device.
 func (d *Device) SetNonblock(nonblocking bool) error {
-	var nonblock C.int
-	if nonblocking {
-		nonblock = 1
-	}
-
-	res := C.hid_set_nonblocking(d.handle, nonblock)
-	if res == -1 {
-		return wrapErr(d.Error())
-	}
-	return nil
+	return d.setNonblock(nonblocking)
 }
 
 // SendFeatureReport sends a feature report with len(p) bytes to the Device.
@@ -277,14 +268,7 @@ func (d *Device) SetNonblock(nonblocking bool) error {
 // The first byte must contain the report ID to send. Data will be sent over
 // the control endpoint as a Set_Report transfer.
 func (d *Device) SendFeatureReport(p []byte) (int, error) {
-	data := (*C.uchar)(&p[0])
-	length := C.size_t(len(p))
-
-	res := C.hid_send_feature_report(d.handle, data, length)
-	if res == -1 {
-		return int(res), wrapErr(d.Error())
-	}
-	return int(res), nil
+	return d.sendFeatureReport(p)
 }
 
 // GetFeatureReport receives a feature report with len(p) bytes from the
@@ -292,148 +276,106 @@ func (d *Device) SendFeatureReport(p []byte) (int, error) {
 //
 // The first byte must contain the report ID to receive.
 func (d *Device) GetFeatureReport(p []byte) (int, error) {
-	data := (*C.uchar)(&p[0])
-	length := C.size_t(len(p))
-
-	res := C.hid_get_feature_report(d.handle, data, length)
-	if res == -1 {
-		return int(res), wrapErr(d.Error())
-	}
-	return int(res), nil
+	return d.getFeatureReport(p)
 }
 
 // GetInputReport receives an input report with len(p) bytes from the Device.
 // It returns the number of bytes read and an error, if any.
 func (d *Device) GetInputReport(p []byte) (int, error) {
-	data := (*C.uchar)(&p[0])
-	length := C.size_t(len(p))
-
-	res := C.hid_get_input_report(d.handle, data, length)
-	if res == -1 {
-		return int(res), wrapErr(d.Error())
-	}
-	return int(res), nil
+	return d.getInputReport(p)
 }
 
 // SendOutputReport sends an output report with len(p) bytes to the Device. It
 // returns the number of bytes written and an error, if any.
 func (d *Device) SendOutputReport(p []byte) (int, error) {
-	data := (*C.uchar)(&p[0])
-	length := C.size_t(len(p))
-
-	res := C.hid_send_output_report(d.handle, data, length)
-	if res == -1 {
-		return int(res), wrapErr(d.Error())
-	}
-	return int(res), nil
+	return d.sendOutputReport(p)
 }
 
 // Close closes the Device.
 func (d *Device) Close() error {
-	C.hid_close(d.handle)
-	return nil
+	return d.close()
 }
 
 // GetMfrStr returns the manufacturer string descriptor and an error, if any.
 func (d *Device) GetMfrStr() (string, error) {
-	wcs := (*C.wchar_t)(calloc(maxStrLen+1, C.sizeof_wchar_t))
-	defer C.free(unsafe.Pointer(wcs))
-
-	res := C.hid_get_manufacturer_string(d.handle, wcs, maxStrLen)
-	if res == -1 {
-		return "", wrapErr(d.Error())
-	}
-	return wcstogo(wcs), nil
+	return d.getMfrStr()
 }
 
 // GetProductStr returns the product string descriptor and an error, if any.
 func (d *Device) GetProductStr() (string, error) {
-	wcs := (*C.wchar_t)(calloc(maxStrLen+1, C.sizeof_wchar_t))
-	defer C.free(unsafe.Pointer(wcs))
-
-	res := C.hid_get_product_string(d.handle, wcs, maxStrLen)
-	if res == -1 {
-		return "", wrapErr(d.Error())
-	}
-	return wcstogo(wcs), nil
+	return d.getProductStr()
 }
 
 // GetSerialNbr returns the serial number string descriptor and an error, if any.
 func (d *Device) GetSerialNbr() (string, error) {
-	wcs := (*C.wchar_t)(calloc(maxStrLen+1, C.sizeof_wchar_t))
-	defer C.free(unsafe.Pointer(wcs))
-
-	res := C.hid_get_serial_number_string(d.handle, wcs, maxStrLen)
-	if res == -1 {
-		return "", wrapErr(d.Error())
-	}
-	return wcstogo(wcs), nil
+	return d.getSerialNbr()
 }
 
 // GetDeviceInfo returns device information and an error, if any.
 func (d *Device) GetDeviceInfo() (*DeviceInfo, error) {
-	p := C.hid_get_device_info(d.handle)
-	if p == nil {
-		return nil, wrapErr(Error())
+	c := d.topLevelCollections()
+	info, err := d.getDeviceInfo()
+	if err != nil {
+		return nil, err
 	}
-	return newDeviceInfo(p), nil
+	info.Collections = copyCollections(c)
+	info.PortPath = portPath(info.Path)
+	return info, nil
 }
 
-// GetIndexedStr returns a string descriptor by index and an error, if any.
-func (d *Device) GetIndexedStr(index int) (string, error) {
-	wcs := (*C.wchar_t)(calloc(maxStrLen+1, C.sizeof_wchar_t))
-	defer C.free(unsafe.Pointer(wcs))
+// topLevelCollections returns the top-level collections parsed from the
+// report descriptor of the Device. The report descriptor is read once, when
+// first needed, and is not reported to the Tracer.
+func (d *Device) topLevelCollections() []Collection {
+	d.collOnce.Do(func() {
+		desc := make([]byte, MaxReportDescriptorSize)
+		if n, err := baseDevice(d.device).getReportDescriptor(desc); err == nil {
+			d.collections, _ = ParseCollections(desc[:n])
+		}
+	})
+	return d.collections
+}
 
-	res := C.hid_get_indexed_string(d.handle, C.int(index), wcs, maxStrLen)
-	if res == -1 {
-		return "", wrapErr(d.Error())
+// copyCollections returns a deep copy of c.
+func copyCollections(c []Collection) []Collection {
+	if c == nil {
+		return nil
+	}
+	cc := make([]Collection, len(c))
+	for i := range c {
+		cc[i] = c[i]
+		cc[i].ReportIDs = append([]byte(nil), c[i].ReportIDs...)
 	}
-	return wcstogo(wcs), nil
+	return cc
+}
+
+// GetIndexedStr returns a string descriptor by index and an error, if any.
+func (d *Device) GetIndexedStr(index int) (string, error) {
+	return d.getIndexedStr(index)
 }
 
 // GetReportDescriptor receives a report descriptor with len(p) bytes from the
 // Device. It returns the number of bytes read and an error, if any.
 func (d *Device) GetReportDescriptor(p []byte) (int, error) {
-	data := (*C.uchar)(&p[0])
-	length := C.size_t(len(p))
-
-	res := C.hid_get_report_descriptor(d.handle, data, length)
-	if res == -1 {
-		return int(res), wrapErr(d.Error())
-	}
-	return int(res), nil
+	return d.getReportDescriptor(p)
 }
 
 // Error returns the last error that occurred on the Device. If no error
 // occurred, nil is returned.
 func (d *Device) Error() error {
-	wcs := C.hid_error(d.handle)
-	if wcs == nil {
-		return nil // no error
-	}
-	return errors.New(wcstogo(wcs))
+	return d.lastError()
 }
 
 // ReadError returns the last error that occurred when reading from the Device.
 // If no error occurred, nil is returned.
 func (d *Device) ReadError() error {
-	wcs := C.hid_read_error(d.handle)
-	if wcs == nil {
-		return nil // no error
-	}
-	return errors.New(wcstogo(wcs))
+	return d.lastReadError()
 }
 
-var _ io.ReadWriteCloser = (*Device)(nil)
-
 // Error returns the last non-device-specific error that occurred. If no error
 // occurred, nil is returned.
 func Error() error {
-	wcs := C.hid_error(nil)
-	if wcs == nil {
-		return nil // no error
-	}
-	return errors.New(wcstogo(wcs))
+	return sysError()
 }
 
 // APIVersion describes the HIDAPI version.
@@ -443,17 +385,27 @@ type APIVersion struct {
 	Patch int // Patch version number
 }
 
-// GetVersion returns the HIDAPI version.
-func GetVersion() APIVersion {
-	v := C.hid_version()
-	return APIVersion{
-		Major: int(v.major),
-		Minor: int(v.minor),
-		Patch: int(v.patch),
+// AtLeast reports whether the version is equal to or later than the version
+// given by major, minor, and patch.
+func (v APIVersion) AtLeast(major, minor, patch int) bool {
+	if v.Major != major {
+		return v.Major > major
 	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Patch >= patch
+}
+
+// GetVersion returns the HIDAPI version. When linking against the system
+// HIDAPI library (systemhidapi build constraint), functions introduced after
+// the reported version return an error; if the version cannot be determined,
+// the zero value is returned.
+func GetVersion() APIVersion {
+	return sysVersion()
 }
 
 // GetVersion returns the HIDAPI version as a string.
 func GetVersionStr() string {
-	return C.GoString(C.hid_version_str())
+	return sysVersionStr()
 }
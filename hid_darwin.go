@@ -26,14 +26,18 @@ package hid
 /*
 #include <stdint.h>
 #include "hidapi_darwin.h"
+#include "hidapi_system.h"
 */
 import "C"
 
 // GetLocationID returns the location ID and an error, if any.
 func (d *Device) GetLocationID() (uint32, error) {
+	if err := requireVersion("hid_darwin_get_location_id", 0, 12, 0); err != nil {
+		return 0, err
+	}
 	var id C.uint32_t
 
-	res := C.hid_darwin_get_location_id(d.handle, &id)
+	res := C.hid_darwin_get_location_id(d.handle(), &id)
 	if res == -1 {
 		return uint32(res), wrapErr(d.Error())
 	}
@@ -62,7 +66,10 @@ func GetOpenExclusive() bool {
 // IsOpenExclusive returns if the device is in exclusive mode and an error, if
 // any.
 func (d *Device) IsOpenExclusive() (bool, error) {
-	res := C.hid_darwin_is_device_open_exclusive(d.handle)
+	if err := requireVersion("hid_darwin_is_device_open_exclusive", 0, 12, 0); err != nil {
+		return false, err
+	}
+	res := C.hid_darwin_is_device_open_exclusive(d.handle())
 	switch res {
 	case -1:
 		return false, wrapErr(d.Error())
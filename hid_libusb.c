@@ -20,7 +20,11 @@
         https://github.com/libusb/hidapi .
 ********************************************************/
 
-//go:build freebsd || (linux && libusb)
+//go:build (freebsd || (linux && cgo && libusb && !purego)) && !systemhidapi
+
+/* Prefix external symbols; see hidapi_namespace.h. */
+#undef GO_HID_NAMESPACE
+#define GO_HID_NAMESPACE go_libusb_
 
 #define _GNU_SOURCE /* needed for wcsdup() before glibc 2.10 */
 
@@ -39,6 +43,7 @@
 #include <sys/ioctl.h>
 #include <sys/utsname.h>
 #include <fcntl.h>
+#include <time.h>
 #include <wchar.h>
 
 /* GNU / LibUSB */
@@ -83,6 +88,7 @@ instead to differentiate between interfaces on a composite HID device. */
 struct input_report {
 	uint8_t *data;
 	size_t len;
+	struct timespec timestamp; /* time received (CLOCK_MONOTONIC) */
 	struct input_report *next;
 };
 
@@ -136,7 +142,7 @@ static struct hid_api_version api_version = {
 static libusb_context *usb_context = NULL;
 
 uint16_t get_usb_code_for_current_locale(void);
-static int return_data(hid_device *dev, unsigned char *data, size_t length);
+static int return_data(hid_device *dev, unsigned char *data, size_t length, struct timespec *timestamp);
 
 static hid_device *new_hid_device(void)
 {
@@ -965,6 +971,7 @@ static void LIBUSB_CALL read_callback(struct libusb_transfer *transfer)
 		memcpy(rpt->data, transfer->buffer, transfer->actual_length);
 		rpt->len = transfer->actual_length;
 		rpt->next = NULL;
+		clock_gettime(CLOCK_MONOTONIC, &rpt->timestamp);
 
 		hidapi_thread_mutex_lock(&dev->thread_state);
 
@@ -988,7 +995,7 @@ static void LIBUSB_CALL read_callback(struct libusb_transfer *transfer)
 			   way we don't grow forever if the user never reads
 			   anything from the device. */
 			if (num_queued > 30) {
-				return_data(dev, NULL, 0);
+				return_data(dev, NULL, 0, NULL);
 			}
 		}
 		hidapi_thread_mutex_unlock(&dev->thread_state);
@@ -1458,7 +1465,7 @@ int HID_API_EXPORT hid_write(hid_device *dev, const unsigned char *data, size_t
 
 /* Helper function, to simplify hid_read().
    This should be called with dev->mutex locked. */
-static int return_data(hid_device *dev, unsigned char *data, size_t length)
+static int return_data(hid_device *dev, unsigned char *data, size_t length, struct timespec *timestamp)
 {
 	/* Copy the data out of the linked list item (rpt) into the
 	   return buffer (data), and delete the liked list item. */
@@ -1466,6 +1473,8 @@ static int return_data(hid_device *dev, unsigned char *data, size_t length)
 	size_t len = (length < rpt->len)? length: rpt->len;
 	if (len > 0)
 		memcpy(data, rpt->data, len);
+	if (timestamp)
+		*timestamp = rpt->timestamp;
 	dev->input_reports = rpt->next;
 	free(rpt->data);
 	free(rpt);
@@ -1479,7 +1488,9 @@ static void cleanup_mutex(void *param)
 }
 
 
-int HID_API_EXPORT hid_read_timeout(hid_device *dev, unsigned char *data, size_t length, int milliseconds)
+/* Helper function for hid_read_timeout() and go_hid_libusb_read_timestamp().
+   If timestamp is not NULL, the time the report was received is stored. */
+static int read_timeout(hid_device *dev, unsigned char *data, size_t length, int milliseconds, struct timespec *timestamp)
 {
 #if 0
 	int transferred;
@@ -1499,7 +1510,7 @@ int HID_API_EXPORT hid_read_timeout(hid_device *dev, unsigned char *data, size_t
 	/* There's an input report queued up. Return it. */
 	if (dev->input_reports) {
 		/* Return the first one */
-		bytes_read = return_data(dev, data, length);
+		bytes_read = return_data(dev, data, length, timestamp);
 		goto ret;
 	}
 
@@ -1516,7 +1527,7 @@ int HID_API_EXPORT hid_read_timeout(hid_device *dev, unsigned char *data, size_t
 			hidapi_thread_cond_wait(&dev->thread_state);
 		}
 		if (dev->input_reports) {
-			bytes_read = return_data(dev, data, length);
+			bytes_read = return_data(dev, data, length, timestamp);
 		}
 	}
 	else if (milliseconds > 0) {
@@ -1530,7 +1541,7 @@ int HID_API_EXPORT hid_read_timeout(hid_device *dev, unsigned char *data, size_t
 			res = hidapi_thread_cond_timedwait(&dev->thread_state, &ts);
 			if (res == 0) {
 				if (dev->input_reports) {
-					bytes_read = return_data(dev, data, length);
+					bytes_read = return_data(dev, data, length, timestamp);
 					break;
 				}
 
@@ -1563,12 +1574,27 @@ ret:
 }
 
 
+int HID_API_EXPORT hid_read_timeout(hid_device *dev, unsigned char *data, size_t length, int milliseconds)
+{
+	return read_timeout(dev, data, length, milliseconds, NULL);
+}
+
+
 int HID_API_EXPORT hid_read(hid_device *dev, unsigned char *data, size_t length)
 {
 	return hid_read_timeout(dev, data, length, dev->blocking ? -1 : 0);
 }
 
 
+/* go_hid_libusb_read_timestamp behaves like hid_read() and stores the time the
+   report was received by read_callback() (CLOCK_MONOTONIC) in timestamp. This
+   function is specific to go-hid and is not part of the HIDAPI API. */
+int HID_API_EXPORT go_hid_libusb_read_timestamp(hid_device *dev, unsigned char *data, size_t length, struct timespec *timestamp)
+{
+	return read_timeout(dev, data, length, dev->blocking ? -1 : 0, timestamp);
+}
+
+
 HID_API_EXPORT const wchar_t * HID_API_CALL hid_read_error(hid_device *dev)
 {
 	(void)dev;
@@ -1739,7 +1765,7 @@ void HID_API_EXPORT hid_close(hid_device *dev)
 	/* Clear out the queue of received reports. */
 	hidapi_thread_mutex_lock(&dev->thread_state);
 	while (dev->input_reports) {
-		return_data(dev, NULL, 0);
+		return_data(dev, NULL, 0, NULL);
 	}
 	hidapi_thread_mutex_unlock(&dev->thread_state);
 
@@ -21,16 +21,19 @@
 // OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
 // SUCH DAMAGE.
 
-//go:build freebsd || (linux && libusb)
+//go:build freebsd || (linux && cgo && libusb && !purego)
 
 package hid
 
 /*
 #include <stdint.h>
 #include "hidapi_libusb.h"
+#include "hidapi_system.h"
 */
 import "C"
 
+import "time"
+
 // InterfaceNbrAny can be passed to the OpenSysDevice function to match any
 // USB interface number.
 const InterfaceNbrAny = -1
@@ -40,9 +43,13 @@ const InterfaceNbrAny = -1
 // descriptor known to libusb and the USB interface number specified by fd and
 // ifnum, respectively.
 func OpenSysDevice(fd uintptr, ifnum int) (*Device, error) {
+	if err := requireVersion("hid_libusb_wrap_sys_device", 0, 11, 0); err != nil {
+		return nil, err
+	}
+	start := time.Now()
 	handle := C.hid_libusb_wrap_sys_device(C.intptr_t(fd), C.int(ifnum))
 	if handle == nil {
-		return nil, wrapErr(Error())
+		return traceOpen(start, "", 0, 0, nil, nil, wrapErr(Error()))
 	}
-	return &Device{handle}, nil
+	return traceOpen(start, "", 0, 0, nil, &Device{device: &hidapiDevice{handle}}, nil)
 }
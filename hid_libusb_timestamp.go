@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:build (freebsd || (linux && cgo && libusb && !purego)) && !systemhidapi
+
+package hid
+
+/*
+#include <stddef.h>
+#include <time.h>
+#include "hidapi.h"
+
+int go_hid_libusb_read_timestamp(hid_device *dev, unsigned char *data, size_t length, struct timespec *timestamp);
+*/
+import "C"
+
+import "time"
+
+func init() {
+	hidapiReadTimestamped = libusbReadTimestamped
+}
+
+// libusbReadTimestamped reads an input report and returns the time the report
+// was received by the libusb transfer callback.
+func libusbReadTimestamped(d *hidapiDevice, p []byte) (int, time.Time, error) {
+	data := (*C.uchar)(&p[0])
+	length := C.size_t(len(p))
+
+	var ts C.struct_timespec
+	res := C.go_hid_libusb_read_timestamp(d.handle, data, length, &ts)
+	switch res {
+	case -1:
+		return int(res), time.Time{}, wrapErr(d.lastReadError())
+	case 0:
+		return int(res), time.Time{}, ErrTimeout
+	}
+	return int(res), monotonicTime(ts), nil
+}
+
+// monotonicTime converts ts, read from CLOCK_MONOTONIC, to a time.Time. The
+// result is relative to the current time so that it retains a monotonic clock
+// reading.
+func monotonicTime(ts C.struct_timespec) time.Time {
+	var now C.struct_timespec
+	t := time.Now()
+	C.clock_gettime(C.CLOCK_MONOTONIC, &now)
+	age := time.Duration(now.tv_sec-ts.tv_sec)*time.Second + time.Duration(now.tv_nsec-ts.tv_nsec)
+	if age < 0 {
+		age = 0
+	}
+	return t.Add(-age)
+}
@@ -20,7 +20,11 @@
         https://github.com/libusb/hidapi .
 ********************************************************/
 
-//go:build !libusb
+//go:build cgo && !purego && !nolibudev && !systemhidapi
+
+/* Prefix external symbols; see hidapi_namespace.h. */
+#undef GO_HID_NAMESPACE
+#define GO_HID_NAMESPACE go_hidraw_
 
 /* C */
 #include <stdio.h>
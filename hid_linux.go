@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// LinuxDeviceInfo describes additional attributes of a HID device attached to
+// the system that are exposed by the Linux kernel through sysfs. Attributes
+// that are not available for a given device are left empty.
+type LinuxDeviceInfo struct {
+	Sysname       string // sysfs Name of HID Device
+	Driver        string // Bound Kernel Driver
+	HidrawMinor   int    // hidraw Minor Number (-1 if unavailable)
+	Phys          string // Physical Location (HID_PHYS)
+	Uniq          string // Unique Identifier (HID_UNIQ)
+	Name          string // Device Name (HID_NAME)
+	PortPath      string // USB Bus/Port Path
+	Speed         string // Negotiated USB Speed in Mbit/s
+	MaxPower      string // USB Maximum Power Consumption (bMaxPower)
+	ParentSyspath string // sysfs Path of Parent Device
+}
+
+// LinuxInfo returns additional device information for the HID device
+// described by info and an error, if any.
+//
+// The HID_PHYS and HID_UNIQ strings are read from the uevent attribute of the
+// HID device and are identical to those returned by the HIDIOCGRAWPHYS and
+// HIDIOCGRAWUNIQ ioctls; as such, the device need not be opened.
+func LinuxInfo(info *DeviceInfo) (*LinuxDeviceInfo, error) {
+	dir, err := hidSysfsDir(info.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", info.Path, err)
+	}
+
+	uevent := sysfsUevent(dir)
+	linuxInfo := &LinuxDeviceInfo{
+		Sysname:       filepath.Base(dir),
+		Driver:        uevent["DRIVER"],
+		HidrawMinor:   hidrawMinor(dir),
+		Phys:          uevent["HID_PHYS"],
+		Uniq:          uevent["HID_UNIQ"],
+		Name:          uevent["HID_NAME"],
+		ParentSyspath: filepath.Dir(dir),
+	}
+	if linuxInfo.Driver == "" {
+		linuxInfo.Driver = sysfsLink(dir, "driver")
+	}
+
+	if usbDev := sysfsParent(dir, "usb", "usb_device"); usbDev != "" {
+		linuxInfo.PortPath = filepath.Base(usbDev)
+		linuxInfo.Speed = sysfsAttr(usbDev, "speed")
+		linuxInfo.MaxPower = sysfsAttr(usbDev, "bMaxPower")
+	}
+	return linuxInfo, nil
+}
+
+// LinuxInfo returns additional device information for the Device and an
+// error, if any.
+func (d *Device) LinuxInfo() (*LinuxDeviceInfo, error) {
+	info, err := d.GetDeviceInfo()
+	if err != nil {
+		return nil, err
+	}
+	return LinuxInfo(info)
+}
+
+// hidrawMinor returns the minor number of the hidraw device node bound to the
+// HID device in the sysfs directory dir, or -1 if none is bound.
+func hidrawMinor(dir string) int {
+	matches, _ := filepath.Glob(filepath.Join(dir, "hidraw", "hidraw*"))
+	for _, match := range matches {
+		var major, minor int
+		if _, err := fmt.Sscanf(strings.TrimSpace(sysfsAttr(match, "dev")), "%d:%d", &major, &minor); err == nil {
+			return minor
+		}
+	}
+	return -1
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLinuxInfo(t *testing.T) {
+	fs := newFakeSysfs(t)
+	want := &LinuxDeviceInfo{
+		Sysname:       "0003:046D:C52B.0001",
+		Driver:        "hid-generic",
+		HidrawMinor:   3,
+		Phys:          "usb-0000:00:14.0-2.4/input0",
+		Name:          "Logitech USB Receiver",
+		PortPath:      "1-2.4",
+		Speed:         "12",
+		MaxPower:      "98mA",
+		ParentSyspath: fs.intf,
+	}
+	for _, path := range []string{"/dev/hidraw3", "1-2.4:1.0"} {
+		got, err := LinuxInfo(&DeviceInfo{Path: path})
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %+v, want %+v", path, got, want)
+		}
+	}
+}
+
+func TestLinuxInfoNoUSB(t *testing.T) {
+	fs := newFakeSysfs(t)
+
+	// Devices not attached to USB (i.e. uhid) report no USB attributes.
+	uhid := filepath.Join(fs.root, "devices/virtual/misc/uhid")
+	fs.move(t, fs.hidDev, filepath.Join(uhid, filepath.Base(fs.hidDev)))
+
+	got, err := LinuxInfo(&DeviceInfo{Path: "/dev/hidraw3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PortPath != "" || got.Speed != "" || got.MaxPower != "" {
+		t.Errorf("unexpected USB attributes: %+v", got)
+	}
+	if got.ParentSyspath != uhid {
+		t.Errorf("got %s, want %s", got.ParentSyspath, uhid)
+	}
+}
+
+func TestPortPath(t *testing.T) {
+	newFakeSysfs(t)
+	for _, path := range []string{"/dev/hidraw3", "1-2.4:1.0"} {
+		if got := portPath(path); got != "1-2.4" {
+			t.Errorf("%s: got %q, want 1-2.4", path, got)
+		}
+	}
+}
@@ -26,6 +26,7 @@ package hid
 /*
 #include <stdlib.h>
 #include "hidapi_winapi.h"
+#include "hidapi_system.h"
 */
 import "C"
 
@@ -38,8 +39,11 @@ import (
 // GetContainerID returns the container ID pointed to by guid and an error, if
 // any.
 func (d *Device) GetContainerID(guid *windows.GUID) error {
+	if err := requireVersion("hid_winapi_get_container_id", 0, 12, 0); err != nil {
+		return err
+	}
 	container_id := (*C.GUID)(unsafe.Pointer(guid))
-	if res := C.hid_winapi_get_container_id(d.handle, container_id); res == -1 {
+	if res := C.hid_winapi_get_container_id(d.handle(), container_id); res == -1 {
 		return wrapErr(d.Error())
 	}
 	return nil
@@ -49,7 +53,7 @@ func (d *Device) GetContainerID(guid *windows.GUID) error {
 // default timeout is 1 second. Setting the timeout to 0 enables non-blocking
 // behavior while -1 blocks until the write completes or returns an error.
 func (d *Device) SetWriteTimeout(timeout int) {
-	C.hid_winapi_set_write_timeout(d.handle, C.ulong(timeout));
+	C.hid_winapi_set_write_timeout(d.handle(), C.ulong(timeout));
 }
 
 // ReconstructDescriptorData reconstructs a HID Report Descriptor from a Win32
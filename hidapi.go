@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:build !(linux && (purego || (nolibudev && !libusb && !systemhidapi)))
+
+package hid
+
+/*
+#cgo darwin,!systemhidapi LDFLAGS: -framework IOKit -framework CoreFoundation
+#cgo freebsd CFLAGS: -I/usr/local/include
+#cgo freebsd,!systemhidapi LDFLAGS: -L/usr/local/lib -lusb -liconv -pthread
+#cgo freebsd,systemhidapi LDFLAGS: -L/usr/local/lib -liconv
+#cgo linux,!nolibudev,!systemhidapi LDFLAGS: -ludev
+#cgo linux,!systemhidapi LDFLAGS: -lrt
+#cgo linux,libusb,!systemhidapi pkg-config: libusb-1.0
+#cgo linux,libusb,!systemhidapi LDFLAGS: -lpthread
+#cgo freebsd CFLAGS: -DGO_HID_NAMESPACE=go_libusb_
+#cgo linux,!libusb CFLAGS: -DGO_HID_NAMESPACE=go_hidraw_
+#cgo linux,libusb CFLAGS: -DGO_HID_NAMESPACE=go_libusb_
+#cgo systemhidapi CFLAGS: -DHIDAPI_SYSTEM
+#cgo systemhidapi,linux,!libusb pkg-config: hidapi-hidraw
+#cgo systemhidapi,linux,libusb pkg-config: hidapi-libusb
+#cgo systemhidapi,!linux pkg-config: hidapi
+
+#include <stdint.h>
+#include <stdlib.h>
+#include "hidapi.h"
+#include "hidapi_system.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxStrLen is the maximum length of a string descriptor (bLength).
+const maxStrLen = math.MaxUint8
+
+func wrapErr(err error) error {
+	if err == nil {
+		return errors.New("unspecified error")
+	}
+	return err
+}
+
+func sysInit() error {
+	if res := C.hid_init(); res == -1 {
+		return wrapErr(Error())
+	}
+	return nil
+}
+
+func sysExit() error {
+	if res := C.hid_exit(); res == -1 {
+		return wrapErr(Error())
+	}
+	return nil
+}
+
+// newDeviceInfo returns the DeviceInfo described by p. The bus_type member was
+// appended to hid_device_info in HIDAPI 0.13.0 and is only read if the library
+// version v is known to provide it; otherwise BusType is BusUnknown.
+func newDeviceInfo(v APIVersion, p *C.struct_hid_device_info) *DeviceInfo {
+	info := &DeviceInfo{
+		Path:         C.GoString(p.path),
+		VendorID:     uint16(p.vendor_id),
+		ProductID:    uint16(p.product_id),
+		SerialNbr:    wcstogo(p.serial_number),
+		ReleaseNbr:   uint16(p.release_number),
+		MfrStr:       wcstogo(p.manufacturer_string),
+		ProductStr:   wcstogo(p.product_string),
+		UsagePage:    uint16(p.usage_page),
+		Usage:        uint16(p.usage),
+		InterfaceNbr: int(p.interface_number),
+	}
+	if v.AtLeast(0, 13, 0) {
+		info.BusType = BusType(p.bus_type)
+	}
+	return info
+}
+
+func hidapiEnumerate(vid, pid uint16) []*DeviceInfo {
+	return hidapiEnumerateVersion(sysVersion(), vid, pid)
+}
+
+// hidapiEnumerateVersion is like hidapiEnumerate, but assumes the HIDAPI
+// library version is v.
+func hidapiEnumerateVersion(v APIVersion, vid, pid uint16) []*DeviceInfo {
+	setError(nil)
+	p := C.hid_enumerate(C.uint16_t(vid), C.uint16_t(pid))
+	defer C.hid_free_enumeration(p)
+
+	var devs []*DeviceInfo
+	for ; p != nil; p = p.next {
+		devs = append(devs, newDeviceInfo(v, p))
+	}
+	return devs
+}
+
+func sysError() error {
+	if err := openError(); err != nil {
+		return err
+	}
+	if C.go_hid_has_version() == 0 {
+		return nil // global errors are not reported prior to 0.10.0
+	}
+	wcs := C.hid_error(nil)
+	if wcs == nil {
+		return nil // no error
+	}
+	return errors.New(wcstogo(wcs))
+}
+
+// requireVersion returns an error if the HIDAPI library is older than the
+// version in which the function fn was introduced. This is only possible when
+// linking against the system library (systemhidapi build constraint).
+func requireVersion(fn string, major, minor, patch int) error {
+	if v := sysVersion(); !v.AtLeast(major, minor, patch) {
+		return fmt.Errorf("%s: requires HIDAPI %d.%d.%d or later (found %s)",
+			fn, major, minor, patch, sysVersionStr())
+	}
+	return nil
+}
+
+func sysVersion() APIVersion {
+	if C.go_hid_has_version() == 0 {
+		return APIVersion{} // hid_version was introduced in 0.10.0
+	}
+	v := C.hid_version()
+	return APIVersion{
+		Major: int(v.major),
+		Minor: int(v.minor),
+		Patch: int(v.patch),
+	}
+}
+
+func sysVersionStr() string {
+	if C.go_hid_has_version() == 0 {
+		return "unknown (prior to 0.10.0)"
+	}
+	return C.GoString(C.hid_version_str())
+}
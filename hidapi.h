@@ -27,6 +27,8 @@
 #ifndef HIDAPI_H__
 #define HIDAPI_H__
 
+#include "hidapi_namespace.h"
+
 #include <wchar.h>
 
 /* #480: this is to be refactored properly for v1.0 */
@@ -0,0 +1,285 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:build !linux || ((libusb || systemhidapi) && !purego)
+
+package hid
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+#include "hidapi.h"
+#include "hidapi_system.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"time"
+	"unsafe"
+)
+
+// hidapiDevice is a HIDAPI device handle.
+type hidapiDevice struct {
+	handle *C.hid_device
+}
+
+// handle returns the HIDAPI device handle of d.
+func (d *Device) handle() *C.hid_device {
+	return baseDevice(d.device).(*hidapiDevice).handle
+}
+
+func hidapiOpen(vid, pid uint16, serial *string) (*Device, error) {
+	var wcs *C.wchar_t
+	if serial != nil {
+		wcs = gotowcs(*serial)
+		defer C.free(unsafe.Pointer(wcs))
+	}
+
+	setError(nil)
+	handle := C.hid_open(C.uint16_t(vid), C.uint16_t(pid), wcs)
+	if handle == nil {
+		return nil, wrapErr(Error())
+	}
+	return &Device{device: &hidapiDevice{handle}}, nil
+}
+
+func hidapiOpenPath(path string) (*Device, error) {
+	cs := C.CString(path)
+	defer C.free(unsafe.Pointer(cs))
+
+	setError(nil)
+	handle := C.hid_open_path(cs)
+	if handle == nil {
+		return nil, wrapErr(Error())
+	}
+	return &Device{device: &hidapiDevice{handle}}, nil
+}
+
+func (d *hidapiDevice) write(p []byte) (int, error) {
+	data := (*C.uchar)(&p[0])
+	length := C.size_t(len(p))
+
+	res := C.hid_write(d.handle, data, length)
+	if res == -1 {
+		return int(res), wrapErr(d.lastError())
+	}
+	return int(res), nil
+}
+
+func (d *hidapiDevice) readTimeout(p []byte, timeout time.Duration) (int, error) {
+	data := (*C.uchar)(&p[0])
+	length := C.size_t(len(p))
+	milliseconds := C.int(timeout / time.Millisecond)
+
+	res := C.hid_read_timeout(d.handle, data, length, milliseconds)
+	switch res {
+	case -1:
+		return int(res), wrapErr(d.lastReadError())
+	case 0:
+		return int(res), ErrTimeout
+	}
+	return int(res), nil
+}
+
+// hidapiReadTimestamped, if set, reads an input report and returns the time the
+// report was received by the backend.
+var hidapiReadTimestamped func(d *hidapiDevice, p []byte) (int, time.Time, error)
+
+func (d *hidapiDevice) readTimestamped(p []byte) (int, time.Time, error) {
+	if hidapiReadTimestamped != nil {
+		return hidapiReadTimestamped(d, p)
+	}
+	n, err := d.read(p)
+	if err != nil {
+		return n, time.Time{}, err
+	}
+	return n, time.Now(), nil
+}
+
+func (d *hidapiDevice) read(p []byte) (int, error) {
+	data := (*C.uchar)(&p[0])
+	length := C.size_t(len(p))
+
+	res := C.hid_read(d.handle, data, length)
+	switch res {
+	case -1:
+		return int(res), wrapErr(d.lastReadError())
+	case 0:
+		return int(res), ErrTimeout
+	}
+	return int(res), nil
+}
+
+func (d *hidapiDevice) setNonblock(nonblocking bool) error {
+	var nonblock C.int
+	if nonblocking {
+		nonblock = 1
+	}
+
+	res := C.hid_set_nonblocking(d.handle, nonblock)
+	if res == -1 {
+		return wrapErr(d.lastError())
+	}
+	return nil
+}
+
+func (d *hidapiDevice) sendFeatureReport(p []byte) (int, error) {
+	data := (*C.uchar)(&p[0])
+	length := C.size_t(len(p))
+
+	res := C.hid_send_feature_report(d.handle, data, length)
+	if res == -1 {
+		return int(res), wrapErr(d.lastError())
+	}
+	return int(res), nil
+}
+
+func (d *hidapiDevice) getFeatureReport(p []byte) (int, error) {
+	data := (*C.uchar)(&p[0])
+	length := C.size_t(len(p))
+
+	res := C.hid_get_feature_report(d.handle, data, length)
+	if res == -1 {
+		return int(res), wrapErr(d.lastError())
+	}
+	return int(res), nil
+}
+
+func (d *hidapiDevice) getInputReport(p []byte) (int, error) {
+	data := (*C.uchar)(&p[0])
+	length := C.size_t(len(p))
+
+	res := C.hid_get_input_report(d.handle, data, length)
+	if res == -1 {
+		return int(res), wrapErr(d.lastError())
+	}
+	return int(res), nil
+}
+
+func (d *hidapiDevice) sendOutputReport(p []byte) (int, error) {
+	if err := requireVersion("hid_send_output_report", 0, 15, 0); err != nil {
+		return -1, err
+	}
+	data := (*C.uchar)(&p[0])
+	length := C.size_t(len(p))
+
+	res := C.hid_send_output_report(d.handle, data, length)
+	if res == -1 {
+		return int(res), wrapErr(d.lastError())
+	}
+	return int(res), nil
+}
+
+func (d *hidapiDevice) close() error {
+	C.hid_close(d.handle)
+	return nil
+}
+
+func (d *hidapiDevice) getMfrStr() (string, error) {
+	wcs := (*C.wchar_t)(calloc(maxStrLen+1, C.sizeof_wchar_t))
+	defer C.free(unsafe.Pointer(wcs))
+
+	res := C.hid_get_manufacturer_string(d.handle, wcs, maxStrLen)
+	if res == -1 {
+		return "", wrapErr(d.lastError())
+	}
+	return wcstogo(wcs), nil
+}
+
+func (d *hidapiDevice) getProductStr() (string, error) {
+	wcs := (*C.wchar_t)(calloc(maxStrLen+1, C.sizeof_wchar_t))
+	defer C.free(unsafe.Pointer(wcs))
+
+	res := C.hid_get_product_string(d.handle, wcs, maxStrLen)
+	if res == -1 {
+		return "", wrapErr(d.lastError())
+	}
+	return wcstogo(wcs), nil
+}
+
+func (d *hidapiDevice) getSerialNbr() (string, error) {
+	wcs := (*C.wchar_t)(calloc(maxStrLen+1, C.sizeof_wchar_t))
+	defer C.free(unsafe.Pointer(wcs))
+
+	res := C.hid_get_serial_number_string(d.handle, wcs, maxStrLen)
+	if res == -1 {
+		return "", wrapErr(d.lastError())
+	}
+	return wcstogo(wcs), nil
+}
+
+func (d *hidapiDevice) getDeviceInfo() (*DeviceInfo, error) {
+	if err := requireVersion("hid_get_device_info", 0, 13, 0); err != nil {
+		return nil, err
+	}
+	p := C.hid_get_device_info(d.handle)
+	if p == nil {
+		return nil, wrapErr(Error())
+	}
+	return newDeviceInfo(sysVersion(), p), nil
+}
+
+func (d *hidapiDevice) getIndexedStr(index int) (string, error) {
+	wcs := (*C.wchar_t)(calloc(maxStrLen+1, C.sizeof_wchar_t))
+	defer C.free(unsafe.Pointer(wcs))
+
+	res := C.hid_get_indexed_string(d.handle, C.int(index), wcs, maxStrLen)
+	if res == -1 {
+		return "", wrapErr(d.lastError())
+	}
+	return wcstogo(wcs), nil
+}
+
+func (d *hidapiDevice) getReportDescriptor(p []byte) (int, error) {
+	if err := requireVersion("hid_get_report_descriptor", 0, 14, 0); err != nil {
+		return -1, err
+	}
+	data := (*C.uchar)(&p[0])
+	length := C.size_t(len(p))
+
+	res := C.hid_get_report_descriptor(d.handle, data, length)
+	if res == -1 {
+		return int(res), wrapErr(d.lastError())
+	}
+	return int(res), nil
+}
+
+func (d *hidapiDevice) lastError() error {
+	wcs := C.hid_error(d.handle)
+	if wcs == nil {
+		return nil // no error
+	}
+	return errors.New(wcstogo(wcs))
+}
+
+func (d *hidapiDevice) lastReadError() error {
+	if !sysVersion().AtLeast(0, 15, 0) {
+		return d.lastError() // read errors are reported by hid_error
+	}
+	wcs := C.hid_read_error(d.handle)
+	if wcs == nil {
+		return nil // no error
+	}
+	return errors.New(wcstogo(wcs))
+}
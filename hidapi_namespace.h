@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ * 1. Redistributions of source code must retain the above copyright
+ *    notice, this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright
+ *    notice, this list of conditions and the following disclaimer in the
+ *    documentation and/or other materials provided with the distribution.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+ * ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+ * FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+ * DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+ * OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+ * HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+ * LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+ * OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+ * SUCH DAMAGE.
+ */
+
+/*
+ * The vendored HIDAPI sources are compiled with their external symbols
+ * prefixed by GO_HID_NAMESPACE so that they do not collide with other copies
+ * of HIDAPI linked into the same program, such as those bundled by other cgo
+ * packages. The hidraw and libusb backends use distinct prefixes (go_hidraw_
+ * and go_libusb_) so that both may be linked into a program built with the
+ * libusb build constraint on Linux; other backends use go_. Each backend
+ * source defines GO_HID_NAMESPACE before including this file, and Go sources
+ * are compiled with the prefix of the default backend (see hidapi.go).
+ * Symbols are not renamed when linking against the system HIDAPI library
+ * (systemhidapi build constraint).
+ */
+
+#ifndef HIDAPI_NAMESPACE_H__
+#define HIDAPI_NAMESPACE_H__
+
+#ifndef HIDAPI_SYSTEM
+#ifndef GO_HID_NAMESPACE
+#define GO_HID_NAMESPACE go_
+#endif
+#define GO_HID_CONCAT_(a, b) a##b
+#define GO_HID_CONCAT(a, b)  GO_HID_CONCAT_(a, b)
+#define GO_HID_SYMBOL(name)  GO_HID_CONCAT(GO_HID_NAMESPACE, name)
+
+#define get_usb_code_for_current_locale           GO_HID_SYMBOL(get_usb_code_for_current_locale)
+#define hid_close                                 GO_HID_SYMBOL(hid_close)
+#define hid_darwin_get_location_id                GO_HID_SYMBOL(hid_darwin_get_location_id)
+#define hid_darwin_get_open_exclusive             GO_HID_SYMBOL(hid_darwin_get_open_exclusive)
+#define hid_darwin_is_device_open_exclusive       GO_HID_SYMBOL(hid_darwin_is_device_open_exclusive)
+#define hid_darwin_set_open_exclusive             GO_HID_SYMBOL(hid_darwin_set_open_exclusive)
+#define hid_enumerate                             GO_HID_SYMBOL(hid_enumerate)
+#define hid_error                                 GO_HID_SYMBOL(hid_error)
+#define hid_exit                                  GO_HID_SYMBOL(hid_exit)
+#define hid_free_enumeration                      GO_HID_SYMBOL(hid_free_enumeration)
+#define hid_get_device_info                       GO_HID_SYMBOL(hid_get_device_info)
+#define hid_get_feature_report                    GO_HID_SYMBOL(hid_get_feature_report)
+#define hid_get_indexed_string                    GO_HID_SYMBOL(hid_get_indexed_string)
+#define hid_get_input_report                      GO_HID_SYMBOL(hid_get_input_report)
+#define hid_get_manufacturer_string               GO_HID_SYMBOL(hid_get_manufacturer_string)
+#define hid_get_product_string                    GO_HID_SYMBOL(hid_get_product_string)
+#define hid_get_report_descriptor                 GO_HID_SYMBOL(hid_get_report_descriptor)
+#define hid_get_serial_number_string              GO_HID_SYMBOL(hid_get_serial_number_string)
+#define hid_init                                  GO_HID_SYMBOL(hid_init)
+#define hid_libusb_wrap_sys_device                GO_HID_SYMBOL(hid_libusb_wrap_sys_device)
+#define hid_open                                  GO_HID_SYMBOL(hid_open)
+#define hid_open_path                             GO_HID_SYMBOL(hid_open_path)
+#define hid_read                                  GO_HID_SYMBOL(hid_read)
+#define hid_read_error                            GO_HID_SYMBOL(hid_read_error)
+#define hid_read_timeout                          GO_HID_SYMBOL(hid_read_timeout)
+#define hid_send_feature_report                   GO_HID_SYMBOL(hid_send_feature_report)
+#define hid_send_output_report                    GO_HID_SYMBOL(hid_send_output_report)
+#define hid_set_nonblocking                       GO_HID_SYMBOL(hid_set_nonblocking)
+#define hid_version                               GO_HID_SYMBOL(hid_version)
+#define hid_version_str                           GO_HID_SYMBOL(hid_version_str)
+#define hid_winapi_descriptor_reconstruct_pp_data GO_HID_SYMBOL(hid_winapi_descriptor_reconstruct_pp_data)
+#define hid_winapi_get_container_id               GO_HID_SYMBOL(hid_winapi_get_container_id)
+#define hid_winapi_set_write_timeout              GO_HID_SYMBOL(hid_winapi_set_write_timeout)
+#define hid_write                                 GO_HID_SYMBOL(hid_write)
+#endif
+
+#endif
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ * 1. Redistributions of source code must retain the above copyright
+ *    notice, this list of conditions and the following disclaimer.
+ * 2. Redistributions in binary form must reproduce the above copyright
+ *    notice, this list of conditions and the following disclaimer in the
+ *    documentation and/or other materials provided with the distribution.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+ * ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+ * IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ * ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+ * FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+ * DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+ * OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+ * HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+ * LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+ * OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+ * SUCH DAMAGE.
+ */
+
+/*
+ * When linking against the system HIDAPI library (systemhidapi build
+ * constraint), functions introduced after HIDAPI 0.9.0 are declared weak on
+ * ELF platforms so that binaries load with older versions of the library.
+ * The version reported by hid_version is checked before these functions are
+ * called. This file must be included after all other HIDAPI headers.
+ */
+
+#ifndef GO_HID_SYSTEM_H
+#define GO_HID_SYSTEM_H
+
+#include "hidapi.h"
+
+#if defined(HIDAPI_SYSTEM) && defined(__ELF__)
+#define GO_HID_WEAK 1
+#pragma weak hid_version
+#pragma weak hid_version_str
+#pragma weak hid_read_error
+#pragma weak hid_send_output_report
+#pragma weak hid_get_device_info
+#pragma weak hid_get_report_descriptor
+#ifdef HIDAPI_LIBUSB_H__
+#pragma weak hid_libusb_wrap_sys_device
+#endif
+#endif
+
+/* go_hid_has_version returns non-zero if hid_version is available. */
+static inline int go_hid_has_version(void)
+{
+#ifdef GO_HID_WEAK
+	return hid_version != NULL;
+#else
+	return 1;
+#endif
+}
+
+#endif /* GO_HID_SYSTEM_H */
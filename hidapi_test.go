@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:build cgo && !(linux && (purego || (nolibudev && !libusb && !systemhidapi)))
+
+package hid
+
+import "testing"
+
+func TestEnumerateBusTypeVersion(t *testing.T) {
+	if !sysVersion().AtLeast(0, 13, 0) {
+		t.Skipf("HIDAPI %s does not report bus types", sysVersionStr())
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer Exit()
+
+	want := hidapiEnumerate(VendorIDAny, ProductIDAny)
+	got := hidapiEnumerateVersion(APIVersion{Major: 0, Minor: 12, Patch: 0}, VendorIDAny, ProductIDAny)
+	if len(got) != len(want) {
+		t.Fatalf("got %d devices, want %d", len(got), len(want))
+	}
+	for i, info := range got {
+		if info.Path != want[i].Path {
+			t.Errorf("device %d: got path %q, want %q", i, info.Path, want[i].Path)
+		}
+		if info.BusType != BusUnknown {
+			t.Errorf("%s: got bus type %v, want %v", info.Path, info.BusType, BusUnknown)
+		}
+	}
+}
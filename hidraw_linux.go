@@ -0,0 +1,352 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Request numbers for hidraw ioctls whose size is variable. These are missing
+// from golang.org/x/sys/unix.
+const (
+	hidiocSFeature = 0x06
+	hidiocGFeature = 0x07
+	hidiocGInput   = 0x0a
+	hidiocSOutput  = 0x0b
+)
+
+// hidIOC returns the ioctl request _IOC(_IOC_WRITE|_IOC_READ, 'H', nr, size).
+// The direction bits share the same encoding on all Linux architectures.
+func hidIOC(nr, size int) uintptr {
+	return 0xc0000000 | uintptr(size)<<16 | 'H'<<8 | uintptr(nr)
+}
+
+// hidrawDevice is a hidraw device handle. The file descriptor is placed in
+// non-blocking mode and registered with the runtime network poller, which
+// allows blocked reads to park the calling goroutine rather than an OS thread.
+type hidrawDevice struct {
+	file     *os.File
+	conn     syscall.RawConn
+	blocking bool
+	info     *DeviceInfo
+	err      error
+	readErr  error
+}
+
+func hidrawEnumerate(vid, pid uint16) []*DeviceInfo {
+	setError(nil)
+	devs, err := sysfsEnumerate(vid, pid)
+	if err != nil {
+		setError(err)
+	}
+	return devs
+}
+
+func hidrawOpen(vid, pid uint16, serial *string) (*Device, error) {
+	devs, err := sysfsEnumerate(vid, pid)
+	if err != nil {
+		return nil, setError(err)
+	}
+	for _, info := range devs {
+		if info.VendorID != vid || info.ProductID != pid {
+			continue
+		}
+		if serial == nil || *serial == info.SerialNbr {
+			return hidrawOpenPath(info.Path)
+		}
+	}
+	return nil, setError(errors.New("Device with requested VID/PID/(SerialNumber) not found"))
+}
+
+func hidrawOpenPath(path string) (*Device, error) {
+	setError(nil)
+
+	fd, err := unix.Open(path, unix.O_RDWR|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, setError(fmt.Errorf("Failed to open a device with path '%s': %w", path, err))
+	}
+
+	// Make sure this is a HIDRAW device - responds to HIDIOCGRDESCSIZE
+	if _, err := unix.IoctlGetInt(fd, unix.HIDIOCGRDESCSIZE); err != nil {
+		unix.Close(fd)
+		return nil, setError(fmt.Errorf("ioctl(GRDESCSIZE) error for '%s', not a HIDRAW device?: %w", path, err))
+	}
+
+	file := os.NewFile(uintptr(fd), path)
+	conn, err := file.SyscallConn()
+	if err != nil {
+		file.Close()
+		return nil, setError(err)
+	}
+	return &Device{device: &hidrawDevice{file: file, conn: conn, blocking: true}}, nil
+}
+
+// setError records err as the last error that occurred on the device and
+// returns it.
+func (d *hidrawDevice) setError(err error) error {
+	d.err = err
+	return err
+}
+
+// control invokes fn with the file descriptor of the device. The descriptor
+// remains valid until fn returns.
+func (d *hidrawDevice) control(fn func(fd int) error) error {
+	var ferr error
+	if err := d.conn.Control(func(fd uintptr) { ferr = fn(int(fd)) }); err != nil {
+		return err
+	}
+	return ferr
+}
+
+func (d *hidrawDevice) write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return -1, d.setError(errors.New("Zero buffer/length"))
+	}
+
+	n, err := d.file.Write(p)
+	if err != nil {
+		return -1, d.setError(err)
+	}
+	d.setError(nil)
+	return n, nil
+}
+
+func (d *hidrawDevice) readTimeout(p []byte, timeout time.Duration) (int, error) {
+	// HIDAPI accepts timeouts in milliseconds; truncate the timeout the
+	// same way so that timeouts below 1ms poll the device as they do for
+	// the other backends.
+	if timeout > 0 {
+		timeout = timeout.Truncate(time.Millisecond)
+	}
+	n, _, err := d.readTime(p, timeout)
+	return n, err
+}
+
+// readTime reads an input report with the specified timeout. It returns the
+// number of bytes read, the time the read from the device completed, and an
+// error, if any.
+func (d *hidrawDevice) readTime(p []byte, timeout time.Duration) (int, time.Time, error) {
+	if len(p) == 0 {
+		d.readErr = errors.New("Zero buffer/length")
+		return -1, time.Time{}, d.readErr
+	}
+	d.readErr = nil
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	var ts time.Time
+	n, err := 0, d.file.SetReadDeadline(deadline)
+	if err == nil {
+		if timeout == 0 {
+			// Poll the device once rather than waiting for it to
+			// become readable.
+			var rerr error
+			if err = d.conn.Read(func(fd uintptr) bool {
+				n, rerr = unix.Read(int(fd), p)
+				ts = time.Now()
+				return true
+			}); err == nil {
+				err = rerr
+			}
+		} else {
+			n, err = d.file.Read(p)
+			ts = time.Now()
+		}
+	}
+
+	switch {
+	case err == nil:
+		return n, ts, nil
+	case errors.Is(err, os.ErrDeadlineExceeded), errors.Is(err, unix.EAGAIN):
+		return 0, time.Time{}, ErrTimeout
+	}
+	d.readErr = err
+	return -1, time.Time{}, err
+}
+
+func (d *hidrawDevice) read(p []byte) (int, error) {
+	n, _, err := d.readTimestamped(p)
+	return n, err
+}
+
+func (d *hidrawDevice) readTimestamped(p []byte) (int, time.Time, error) {
+	if d.blocking {
+		return d.readTime(p, -1)
+	}
+	return d.readTime(p, 0)
+}
+
+// setNonblock changes the behavior of subsequent reads. As with HIDAPI, a
+// read already blocked in another goroutine is not woken; it returns once a
+// report is received or the device is closed.
+func (d *hidrawDevice) setNonblock(nonblocking bool) error {
+	d.blocking = !nonblocking
+	return nil
+}
+
+// ioctlReport issues the hidraw ioctl nr with a buffer of len(p) bytes. It
+// returns the result of the ioctl and an error, if any.
+func (d *hidrawDevice) ioctlReport(name string, nr int, p []byte) (int, error) {
+	if len(p) == 0 {
+		return -1, d.setError(errors.New("Zero buffer/length"))
+	}
+
+	var res uintptr
+	err := d.control(func(fd int) error {
+		var errno syscall.Errno
+		res, _, errno = unix.Syscall(unix.SYS_IOCTL, uintptr(fd), hidIOC(nr, len(p)), uintptr(unsafe.Pointer(&p[0])))
+		if errno != 0 {
+			return errno
+		}
+		return nil
+	})
+	if err != nil {
+		return -1, d.setError(fmt.Errorf("ioctl (%s): %w", name, err))
+	}
+	d.setError(nil)
+	return int(res), nil
+}
+
+func (d *hidrawDevice) sendFeatureReport(p []byte) (int, error) {
+	return d.ioctlReport("SFEATURE", hidiocSFeature, p)
+}
+
+func (d *hidrawDevice) getFeatureReport(p []byte) (int, error) {
+	return d.ioctlReport("GFEATURE", hidiocGFeature, p)
+}
+
+func (d *hidrawDevice) getInputReport(p []byte) (int, error) {
+	return d.ioctlReport("GINPUT", hidiocGInput, p)
+}
+
+func (d *hidrawDevice) sendOutputReport(p []byte) (int, error) {
+	return d.ioctlReport("SOUTPUT", hidiocSOutput, p)
+}
+
+func (d *hidrawDevice) close() error {
+	return d.file.Close()
+}
+
+// deviceInfo returns the cached device information for the device.
+func (d *hidrawDevice) deviceInfo() (*DeviceInfo, error) {
+	if d.info != nil {
+		d.setError(nil)
+		return d.info, nil
+	}
+
+	var st unix.Stat_t
+	if err := d.control(func(fd int) error { return unix.Fstat(fd, &st) }); err != nil {
+		return nil, d.setError(errors.New("Failed to stat device handle"))
+	}
+	dev := fmt.Sprintf("%d:%d", unix.Major(uint64(st.Rdev)), unix.Minor(uint64(st.Rdev)))
+	syspath, err := filepath.EvalSymlinks(sysfsPath("dev", "char", dev))
+	if err != nil {
+		return nil, d.setError(errors.New("Couldn't create hid_device_info"))
+	}
+	infos := sysfsDeviceInfo(syspath)
+	if len(infos) == 0 {
+		return nil, d.setError(errors.New("Couldn't create hid_device_info"))
+	}
+	d.info = infos[0]
+	return d.info, d.setError(nil)
+}
+
+func (d *hidrawDevice) getMfrStr() (string, error) {
+	info, err := d.deviceInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.MfrStr, nil
+}
+
+func (d *hidrawDevice) getProductStr() (string, error) {
+	info, err := d.deviceInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.ProductStr, nil
+}
+
+func (d *hidrawDevice) getSerialNbr() (string, error) {
+	info, err := d.deviceInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.SerialNbr, nil
+}
+
+func (d *hidrawDevice) getDeviceInfo() (*DeviceInfo, error) {
+	info, err := d.deviceInfo()
+	if err != nil {
+		return nil, err
+	}
+	c := *info
+	return &c, nil
+}
+
+func (d *hidrawDevice) getIndexedStr(index int) (string, error) {
+	return "", d.setError(errors.New("hid_get_indexed_string: not supported by hidraw"))
+}
+
+func (d *hidrawDevice) getReportDescriptor(p []byte) (int, error) {
+	if len(p) == 0 {
+		return -1, d.setError(errors.New("Zero buffer/length"))
+	}
+
+	var desc unix.HIDRawReportDescriptor
+	err := d.control(func(fd int) error {
+		size, err := unix.IoctlGetInt(fd, unix.HIDIOCGRDESCSIZE)
+		if err != nil {
+			return fmt.Errorf("ioctl(GRDESCSIZE): %w", err)
+		}
+		desc.Size = uint32(size)
+		if err := unix.IoctlHIDGetDesc(fd, &desc); err != nil {
+			return fmt.Errorf("ioctl(GRDESC): %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return -1, d.setError(err)
+	}
+	d.setError(nil)
+	return copy(p, desc.Value[:desc.Size]), nil
+}
+
+func (d *hidrawDevice) lastError() error {
+	return d.err
+}
+
+func (d *hidrawDevice) lastReadError() error {
+	return d.readErr
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// newPipeDevice returns a device whose reads are serviced by the read end of
+// a pipe, along with the write end.
+func newPipeDevice(t *testing.T) (*hidrawDevice, *os.File) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.Close(); w.Close() })
+	conn, err := r.SyscallConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &hidrawDevice{file: r, conn: conn, blocking: true}, w
+}
+
+func TestReadTimeout(t *testing.T) {
+	d, w := newPipeDevice(t)
+	p := make([]byte, 8)
+
+	for _, timeout := range []time.Duration{0, 500 * time.Microsecond, 10 * time.Millisecond} {
+		if n, err := d.readTimeout(p, timeout); n != 0 || err != ErrTimeout {
+			t.Errorf("timeout %v: got %d, %v; want 0, ErrTimeout", timeout, n, err)
+		}
+	}
+
+	if _, err := w.Write([]byte{0x01, 0x02}); err != nil {
+		t.Fatal(err)
+	}
+	for _, timeout := range []time.Duration{0, -1} {
+		n, err := d.readTimeout(p[:1], timeout)
+		if n != 1 || err != nil {
+			t.Errorf("timeout %v: got %d, %v; want 1, nil", timeout, n, err)
+		}
+	}
+}
+
+func TestReadNonblock(t *testing.T) {
+	d, _ := newPipeDevice(t)
+	if err := d.setNonblock(true); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := d.read(make([]byte, 8)); n != 0 || err != ErrTimeout {
+		t.Errorf("got %d, %v; want 0, ErrTimeout", n, err)
+	}
+}
+
+func TestReadTimestamped(t *testing.T) {
+	d, w := newPipeDevice(t)
+	p := make([]byte, 8)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte{0x01, 0x02})
+	}()
+	before := time.Now()
+	n, ts, err := d.readTimestamped(p)
+	after := time.Now()
+	if n != 2 || err != nil {
+		t.Fatalf("got %d, %v; want 2, nil", n, err)
+	}
+	if ts.Before(before) || ts.After(after) {
+		t.Errorf("got timestamp %v; want between %v and %v", ts, before, after)
+	}
+
+	if err := d.setNonblock(true); err != nil {
+		t.Fatal(err)
+	}
+	if n, ts, err := d.readTimestamped(p); n != 0 || !ts.IsZero() || err != ErrTimeout {
+		t.Errorf("got %d, %v, %v; want 0, zero time, ErrTimeout", n, ts, err)
+	}
+}
+
+func TestReadClose(t *testing.T) {
+	d, _ := newPipeDevice(t)
+	errc := make(chan error, 1)
+	go func() {
+		_, err := d.read(make([]byte, 8))
+		errc <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	d.close()
+
+	select {
+	case err := <-errc:
+		if err == nil || err == ErrTimeout {
+			t.Errorf("got %v; want read error", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked read not interrupted by close")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:build linux && (purego || !cgo || (nolibudev && !libusb && !systemhidapi))
+
+package hid
+
+import "fmt"
+
+// apiVersion is the HIDAPI version implemented by the pure Go backend.
+var apiVersion = APIVersion{Major: 0, Minor: 15, Patch: 0}
+
+func sysInit() error {
+	setError(nil)
+	return nil
+}
+
+func sysExit() error {
+	setError(nil)
+	return nil
+}
+
+var sysBackends = []Backend{BackendHidraw}
+
+func sysEnumerate(_ Backend, vid, pid uint16) []*DeviceInfo {
+	return hidrawEnumerate(vid, pid)
+}
+
+func sysError() error {
+	return openError()
+}
+
+func sysVersion() APIVersion {
+	return apiVersion
+}
+
+func sysVersionStr() string {
+	return fmt.Sprintf("%d.%d.%d", apiVersion.Major, apiVersion.Minor, apiVersion.Patch)
+}
@@ -0,0 +1,374 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package conformance
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// timeout is the time the suite waits for a device to respond.
+const timeout = 5 * time.Second
+
+// Config describes a device to be created by a Harness. Reports passed to and
+// returned by the functions in Config begin with the report ID, which is 0 for
+// devices which do not use numbered reports.
+type Config struct {
+	VendorID         uint16 // Device Vendor ID
+	ProductID        uint16 // Device Product ID
+	SerialNbr        string // Serial Number
+	ReportDescriptor []byte // Report Descriptor
+
+	// GetReport returns the feature or input report with the given ID.
+	GetReport func(typ hid.ReportType, id byte) []byte
+
+	// SetFeature is called when a feature report is sent to the device.
+	SetFeature func(report []byte)
+
+	// Output is called when an output report is sent to the device, either
+	// by Write or SendOutputReport.
+	Output func(report []byte)
+}
+
+// Peer is the device side of a device under test.
+type Peer interface {
+	// Input sends an input report to the host. The report begins with
+	// the report ID only if the device uses numbered reports.
+	Input(report []byte) error
+}
+
+// Harness creates a device described by config and returns the device under
+// test and its peer. The device under test is closed by the suite; other
+// resources should be released using t.Cleanup. Harnesses which cannot create
+// devices should call t.Skip.
+type Harness func(t *testing.T, config *Config) (hid.DeviceIO, Peer)
+
+// Deviations describes documented deviations from the contract pinned down by
+// the suite; see the package documentation for details.
+type Deviations struct {
+	WritePadded        bool // Write Pads Reports to the Declared Size
+	NoSendOutputReport bool // SendOutputReport Not Supported
+	NoGetInputReport   bool // GetInputReport Not Supported
+	NoReportDescriptor bool // GetReportDescriptor Not Exact
+}
+
+// numberedDesc describes a vendor-defined device with an 8-byte input report
+// (ID 1), an 8-byte output report (ID 2), and an 8-byte feature report (ID 3).
+var numberedDesc = []byte{
+	0x06, 0x00, 0xff, // Usage Page (Vendor Defined 0xFF00)
+	0x09, 0x01, // Usage (0x01)
+	0xa1, 0x01, // Collection (Application)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x26, 0xff, 0x00, //   Logical Maximum (255)
+	0x75, 0x08, //   Report Size (8)
+	0x95, 0x08, //   Report Count (8)
+	0x85, 0x01, //   Report ID (1)
+	0x09, 0x01, //   Usage (0x01)
+	0x81, 0x02, //   Input (Data,Var,Abs)
+	0x85, 0x02, //   Report ID (2)
+	0x09, 0x01, //   Usage (0x01)
+	0x91, 0x02, //   Output (Data,Var,Abs)
+	0x85, 0x03, //   Report ID (3)
+	0x09, 0x01, //   Usage (0x01)
+	0xb1, 0x02, //   Feature (Data,Var,Abs)
+	0xc0, //       End Collection
+}
+
+// unnumberedDesc describes a vendor-defined device with an 8-byte input,
+// output, and feature report, none of which are numbered.
+var unnumberedDesc = []byte{
+	0x06, 0x00, 0xff, // Usage Page (Vendor Defined 0xFF00)
+	0x09, 0x01, // Usage (0x01)
+	0xa1, 0x01, // Collection (Application)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x26, 0xff, 0x00, //   Logical Maximum (255)
+	0x75, 0x08, //   Report Size (8)
+	0x95, 0x08, //   Report Count (8)
+	0x09, 0x01, //   Usage (0x01)
+	0x81, 0x02, //   Input (Data,Var,Abs)
+	0x09, 0x01, //   Usage (0x01)
+	0x91, 0x02, //   Output (Data,Var,Abs)
+	0x09, 0x01, //   Usage (0x01)
+	0xb1, 0x02, //   Feature (Data,Var,Abs)
+	0xc0, //       End Collection
+}
+
+// reportIDs contains the report IDs declared by a descriptor.
+type reportIDs struct {
+	input, output, feature byte
+}
+
+// report returns an 8-byte report with the given ID whose contents are
+// derived from seed.
+func report(id, seed byte) []byte {
+	r := []byte{id}
+	for i := byte(0); i < 8; i++ {
+		r = append(r, seed+i)
+	}
+	return r
+}
+
+// Run runs the conformance suite against devices created by h. Deviations
+// from the contract documented by dev are tolerated.
+func Run(t *testing.T, h Harness, dev Deviations) {
+	t.Run("Numbered", func(t *testing.T) {
+		run(t, h, dev, numberedDesc, reportIDs{1, 2, 3})
+	})
+	t.Run("Unnumbered", func(t *testing.T) {
+		run(t, h, dev, unnumberedDesc, reportIDs{0, 0, 0})
+	})
+}
+
+// suite holds the state of a device under test.
+type suite struct {
+	config   Config
+	dev      hid.DeviceIO
+	peer     Peer
+	devs     Deviations
+	ids      reportIDs
+	outputs  chan []byte
+	features chan []byte
+}
+
+func run(t *testing.T, h Harness, dev Deviations, desc []byte, ids reportIDs) {
+	s := &suite{
+		devs:     dev,
+		ids:      ids,
+		outputs:  make(chan []byte, 16),
+		features: make(chan []byte, 16),
+	}
+	s.config = Config{
+		VendorID:         0x1209,
+		ProductID:        0x0001,
+		SerialNbr:        "conformance",
+		ReportDescriptor: desc,
+		GetReport: func(typ hid.ReportType, id byte) []byte {
+			switch {
+			case typ == hid.FeatureReport && id == ids.feature:
+				return report(id, 0x30)
+			case typ == hid.InputReport && id == ids.input:
+				return report(id, 0x40)
+			}
+			return nil
+		},
+		SetFeature: func(report []byte) {
+			s.features <- append([]byte(nil), report...)
+		},
+		Output: func(report []byte) {
+			s.outputs <- append([]byte(nil), report...)
+		},
+	}
+	s.dev, s.peer = h(t, &s.config)
+
+	t.Run("Write", s.testWrite)
+	t.Run("Read", s.testRead)
+	t.Run("ReadTimeout", s.testReadTimeout)
+	t.Run("Nonblock", s.testNonblock)
+	t.Run("SendFeatureReport", s.testSendFeatureReport)
+	t.Run("GetFeatureReport", s.testGetFeatureReport)
+	t.Run("GetInputReport", s.testGetInputReport)
+	t.Run("SendOutputReport", s.testSendOutputReport)
+	t.Run("GetReportDescriptor", s.testGetReportDescriptor)
+	t.Run("GetDeviceInfo", s.testGetDeviceInfo)
+	t.Run("Close", s.testClose)
+}
+
+// wait waits for a report to be received on c and compares it to want.
+func (s *suite) wait(t *testing.T, c <-chan []byte, want []byte) {
+	t.Helper()
+	select {
+	case got := <-c:
+		if s.devs.WritePadded && len(got) > len(want) {
+			got = got[:len(want)]
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("device received %x, want %x", got, want)
+		}
+	case <-time.After(timeout):
+		t.Error("timeout waiting for device to receive report")
+	}
+}
+
+// checkWritten checks the number of bytes written for a report of size want.
+func (s *suite) checkWritten(t *testing.T, n int, err error, want int) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != want && !(s.devs.WritePadded && n > want) {
+		t.Errorf("got %d bytes written, want %d", n, want)
+	}
+}
+
+func (s *suite) testWrite(t *testing.T) {
+	r := report(s.ids.output, 0x10)
+	n, err := s.dev.Write(r)
+	s.checkWritten(t, n, err, len(r))
+	s.wait(t, s.outputs, r)
+}
+
+// input sends an input report from the peer and returns it as it should be
+// read by the host.
+func (s *suite) input(t *testing.T, seed byte) []byte {
+	t.Helper()
+	r := report(s.ids.input, seed)
+	if s.ids.input == 0 {
+		r = r[1:]
+	}
+	if err := s.peer.Input(r); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func (s *suite) testRead(t *testing.T) {
+	b := make([]byte, 65)
+	want := s.input(t, 0x20)
+	n, err := s.dev.ReadWithTimeout(b, timeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b[:n], want) {
+		t.Errorf("ReadWithTimeout: got %x, want %x", b[:n], want)
+	}
+
+	want = s.input(t, 0x28)
+	n, err = s.dev.Read(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b[:n], want) {
+		t.Errorf("Read: got %x, want %x", b[:n], want)
+	}
+}
+
+func (s *suite) testReadTimeout(t *testing.T) {
+	n, err := s.dev.ReadWithTimeout(make([]byte, 65), 10*time.Millisecond)
+	if !errors.Is(err, hid.ErrTimeout) {
+		t.Errorf("got %v, want %v", err, hid.ErrTimeout)
+	}
+	if n != 0 {
+		t.Errorf("got %d bytes read, want 0", n)
+	}
+}
+
+func (s *suite) testNonblock(t *testing.T) {
+	if err := s.dev.SetNonblock(true); err != nil {
+		t.Fatal(err)
+	}
+	defer s.dev.SetNonblock(false)
+	n, err := s.dev.Read(make([]byte, 65))
+	if !errors.Is(err, hid.ErrTimeout) {
+		t.Errorf("got %v, want %v", err, hid.ErrTimeout)
+	}
+	if n != 0 {
+		t.Errorf("got %d bytes read, want 0", n)
+	}
+}
+
+func (s *suite) testSendFeatureReport(t *testing.T) {
+	r := report(s.ids.feature, 0x50)
+	n, err := s.dev.SendFeatureReport(r)
+	s.checkWritten(t, n, err, len(r))
+	s.wait(t, s.features, r)
+}
+
+// testGetReport tests getting a report of type typ using fn.
+func (s *suite) testGetReport(t *testing.T, typ hid.ReportType, id byte, fn func([]byte) (int, error)) {
+	b := make([]byte, 65)
+	b[0] = id
+	n, err := fn(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := s.config.GetReport(typ, id); !bytes.Equal(b[:n], want) {
+		t.Errorf("got %x (%d bytes), want %x (%d bytes)", b[:n], n, want, len(want))
+	}
+}
+
+func (s *suite) testGetFeatureReport(t *testing.T) {
+	s.testGetReport(t, hid.FeatureReport, s.ids.feature, s.dev.GetFeatureReport)
+}
+
+func (s *suite) testGetInputReport(t *testing.T) {
+	if s.devs.NoGetInputReport {
+		t.Skip("GetInputReport not supported")
+	}
+	s.testGetReport(t, hid.InputReport, s.ids.input, s.dev.GetInputReport)
+}
+
+func (s *suite) testSendOutputReport(t *testing.T) {
+	if s.devs.NoSendOutputReport {
+		t.Skip("SendOutputReport not supported")
+	}
+	r := report(s.ids.output, 0x60)
+	n, err := s.dev.SendOutputReport(r)
+	s.checkWritten(t, n, err, len(r))
+	s.wait(t, s.outputs, r)
+}
+
+func (s *suite) testGetReportDescriptor(t *testing.T) {
+	b := make([]byte, 4096)
+	n, err := s.dev.GetReportDescriptor(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.devs.NoReportDescriptor {
+		if _, err := hid.ParseReportSizes(b[:n]); err != nil {
+			t.Error(err)
+		}
+		return
+	}
+	if want := s.config.ReportDescriptor; !bytes.Equal(b[:n], want) {
+		t.Errorf("got %x, want %x", b[:n], want)
+	}
+}
+
+func (s *suite) testGetDeviceInfo(t *testing.T) {
+	info, err := s.dev.GetDeviceInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.VendorID != s.config.VendorID || info.ProductID != s.config.ProductID {
+		t.Errorf("got %04x:%04x, want %04x:%04x", info.VendorID, info.ProductID,
+			s.config.VendorID, s.config.ProductID)
+	}
+	str, err := s.dev.GetSerialNbr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if str != s.config.SerialNbr {
+		t.Errorf("got serial number %q, want %q", str, s.config.SerialNbr)
+	}
+}
+
+func (s *suite) testClose(t *testing.T) {
+	if err := s.dev.Close(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+// Package conformance provides a test suite which verifies that a hid.DeviceIO
+// implementation conforms to the contract of hid.Device.
+//
+// The suite is run against devices created by a Harness, which connects a
+// device under test to a peer controlled by the suite. UHID creates virtual
+// devices using the uhid driver on Linux, which are opened using the hid
+// package; Simulator creates devices using hidtest.Simulator. Other backends
+// may be tested by implementing Harness:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, conformance.UHID, conformance.Deviations{})
+//	}
+//
+// Each device is tested using a descriptor which declares numbered reports
+// and a descriptor which declares a single unnumbered report of each type. The
+// suite pins down the following behavior:
+//
+//   - Write and SendOutputReport deliver the report, including the report ID
+//     (0 for unnumbered reports), and return len(p).
+//   - Read and ReadWithTimeout return the input report exactly as sent by the
+//     device; the report ID is included only for numbered reports.
+//   - ReadWithTimeout returns 0 and hid.ErrTimeout if no report is available
+//     before the timeout expires, as does Read in nonblocking mode.
+//   - SendFeatureReport delivers the report, including the report ID, and
+//     returns len(p).
+//   - GetFeatureReport and GetInputReport return the report beginning with
+//     the report ID in p[0], and count the report ID byte in the number of
+//     bytes read, even for unnumbered reports.
+//   - GetReportDescriptor returns the report descriptor of the device.
+//   - GetDeviceInfo and GetSerialNbr describe the device.
+//
+// Backends deviate from this contract in the following ways, which are
+// described by Deviations:
+//
+//   - windows: Write returns the size of the output report declared by the
+//     report descriptor plus the report ID byte, and pads the report with
+//     zeros (WritePadded).
+//   - windows: GetReportDescriptor reconstructs the descriptor from the
+//     preparsed data of the device, which may differ byte-for-byte from the
+//     original (NoReportDescriptor).
+//   - HIDAPI versions before 0.15.0: SendOutputReport is not supported
+//     (NoSendOutputReport).
+//
+// The hidraw backend and hidtest.Simulator are expected to conform without
+// deviations.
+package conformance
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package conformance
+
+import (
+	"testing"
+
+	"github.com/sstallion/go-hid"
+	"github.com/sstallion/go-hid/hidtest"
+)
+
+// simulatorPeer sends input reports using a hidtest.Simulator.
+type simulatorPeer struct {
+	*hidtest.Simulator
+}
+
+func (p simulatorPeer) Input(report []byte) error {
+	p.Send(report)
+	return nil
+}
+
+// Simulator is a Harness which creates devices using hidtest.Simulator.
+func Simulator(t *testing.T, config *Config) (hid.DeviceIO, Peer) {
+	info := hid.DeviceInfo{
+		VendorID:  config.VendorID,
+		ProductID: config.ProductID,
+		SerialNbr: config.SerialNbr,
+	}
+	s, err := hidtest.NewSimulator(info, config.ReportDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sizes, err := hid.ParseReportSizes(config.ReportDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id := range sizes[hid.FeatureReport] {
+		id := id
+		s.OnGetFeature(id, func() []byte { return config.GetReport(hid.FeatureReport, id) })
+		s.OnSetFeature(id, config.SetFeature)
+	}
+	for id := range sizes[hid.InputReport] {
+		id := id
+		s.OnGetInput(id, func() []byte { return config.GetReport(hid.InputReport, id) })
+	}
+	for id := range sizes[hid.OutputReport] {
+		s.OnOutput(id, config.Output)
+	}
+	return s, simulatorPeer{s}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package conformance
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/sstallion/go-hid"
+	"github.com/sstallion/go-hid/uhid"
+)
+
+// UHID is a Harness which creates virtual devices using the uhid driver and
+// opens them using hid.OpenPath. The test is skipped if /dev/uhid is not
+// available.
+func UHID(t *testing.T, config *Config) (hid.DeviceIO, Peer) {
+	d, err := uhid.Create(uhid.Config{
+		Name:             "go-hid conformance",
+		Uniq:             config.SerialNbr,
+		BusType:          hid.BusUSB,
+		VendorID:         config.VendorID,
+		ProductID:        config.ProductID,
+		ReportDescriptor: config.ReportDescriptor,
+		GetReport: func(typ hid.ReportType, id byte) ([]byte, error) {
+			if r := config.GetReport(typ, id); r != nil {
+				return r, nil
+			}
+			return nil, errors.New("report not found")
+		},
+		SetReport: func(typ hid.ReportType, id byte, data []byte) error {
+			switch typ {
+			case hid.FeatureReport:
+				config.SetFeature(data)
+			case hid.OutputReport:
+				config.Output(data)
+			}
+			return nil
+		},
+		Output: func(typ hid.ReportType, data []byte) {
+			config.Output(data)
+		},
+	})
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { d.Close() })
+
+	path, err := d.Path(timeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev, err := hid.OpenPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dev, d
+}
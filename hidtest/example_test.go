@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hidtest_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/sstallion/go-hid"
+	"github.com/sstallion/go-hid/hidtest"
+)
+
+// readButton reads the state of a button from a device opened using e. In
+// production, hid.System is passed.
+func readButton(e hid.Enumerator) (bool, error) {
+	d, err := e.OpenFirst(0x04d8, 0x003f)
+	if err != nil {
+		return false, err
+	}
+	defer d.Close()
+
+	if _, err := d.Write([]byte{0x00, 0x81}); err != nil {
+		return false, err
+	}
+	b := make([]byte, 65)
+	if _, err := d.Read(b); err != nil {
+		return false, err
+	}
+	return b[2] == 0x00, nil
+}
+
+func Example() {
+	d := hidtest.NewDevice(hid.DeviceInfo{VendorID: 0x04d8, ProductID: 0x003f})
+	d.Respond([]byte{0x00, 0x81}, []byte{0x00, 0x81, 0x00})
+
+	pressed, err := readButton(hidtest.NewEnumerator(d))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("pressed:", pressed)
+	// Output: pressed: true
+}
@@ -0,0 +1,418 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hidtest
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+var (
+	// ErrInjected is returned by operations which fail due to an injected
+	// fault, unless another error is specified by the fault.
+	ErrInjected = errors.New("injected fault")
+
+	// ErrDisconnected is returned by all operations on a FaultDevice once
+	// it has been disconnected, unless another error is specified by the
+	// fault.
+	ErrDisconnected = errors.New("device disconnected")
+)
+
+// FaultKind specifies the kind of fault injected by a FaultDevice.
+type FaultKind int
+
+const (
+	// FaultTimeout causes Read and ReadWithTimeout to return hid.ErrTimeout
+	// without reading from the device.
+	FaultTimeout FaultKind = iota + 1
+
+	// FaultShortRead truncates the report returned by Read,
+	// ReadWithTimeout, GetFeatureReport, and GetInputReport.
+	FaultShortRead
+
+	// FaultCorrupt flips a single bit of the report returned by Read,
+	// ReadWithTimeout, GetFeatureReport, and GetInputReport.
+	FaultCorrupt
+
+	// FaultWriteError causes Write, SendFeatureReport, and
+	// SendOutputReport to fail without writing to the device.
+	FaultWriteError
+
+	// FaultDisconnect closes the device; all subsequent operations fail.
+	FaultDisconnect
+
+	// FaultLatency delays an operation.
+	FaultLatency
+)
+
+var faultKindStrings = map[FaultKind]string{
+	FaultTimeout:    "timeout",
+	FaultShortRead:  "short read",
+	FaultCorrupt:    "corrupt",
+	FaultWriteError: "write error",
+	FaultDisconnect: "disconnect",
+	FaultLatency:    "latency",
+}
+
+func (k FaultKind) String() string {
+	if s, ok := faultKindStrings[k]; ok {
+		return s
+	}
+	return "unknown"
+}
+
+// opClass classifies operations to which faults may apply.
+type opClass int
+
+const (
+	opRead      opClass = iota // Read, ReadWithTimeout
+	opGetReport                // GetFeatureReport, GetInputReport
+	opWrite                    // Write, SendFeatureReport, SendOutputReport
+	opOther
+)
+
+// applies reports whether faults of kind k apply to operations of class c.
+func (k FaultKind) applies(c opClass) bool {
+	switch k {
+	case FaultTimeout:
+		return c == opRead
+	case FaultShortRead, FaultCorrupt:
+		return c == opRead || c == opGetReport
+	case FaultWriteError:
+		return c == opWrite
+	}
+	return true
+}
+
+// Fault describes a fault and the schedule on which it is injected. Only
+// operations to which the kind of fault applies are counted. For example, the
+// following fault corrupts about half of the input reports after the first
+// 10, chosen at random using the seed passed to NewFaultDevice:
+//
+//	hidtest.Fault{Kind: hidtest.FaultCorrupt, After: 10, Probability: 0.5}
+type Fault struct {
+	Kind        FaultKind     // Kind of Fault
+	After       int           // Number of Operations to Skip
+	Count       int           // Maximum Number of Injections (0 is Unlimited)
+	Probability float64       // Probability of Injection (0 is Always)
+	Latency     time.Duration // Delay for FaultLatency
+	Err         error         // Error for FaultWriteError and FaultDisconnect
+}
+
+// faultState tracks the schedule of a Fault.
+type faultState struct {
+	Fault
+	ops      int
+	injected int
+}
+
+// action describes the faults to apply to an operation.
+type action struct {
+	delay   time.Duration
+	timeout bool
+	short   bool
+	corrupt bool
+	err     error
+}
+
+// FaultDevice is a hid.DeviceIO which injects faults into operations on
+// another hid.DeviceIO, which may be a real device or a fake device from this
+// package. Faults are injected on the schedule described by each Fault.
+type FaultDevice struct {
+	dev hid.DeviceIO
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	faults   []*faultState
+	injected int
+	closed   bool
+	err      error // set once disconnected
+}
+
+// NewFaultDevice returns a new FaultDevice which injects faults into
+// operations on dev. The seed initializes the source of randomness for
+// faults injected with a probability, which allows schedules to be repeated.
+func NewFaultDevice(dev hid.DeviceIO, seed int64, faults ...Fault) *FaultDevice {
+	f := &FaultDevice{dev: dev, rand: rand.New(rand.NewSource(seed))}
+	for _, fault := range faults {
+		f.faults = append(f.faults, &faultState{Fault: fault})
+	}
+	return f
+}
+
+// Injected returns the number of faults injected.
+func (f *FaultDevice) Injected() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.injected
+}
+
+// Disconnect disconnects the device immediately; pending operations on the
+// underlying device are interrupted and all subsequent operations return err,
+// or ErrDisconnected if err is nil.
+func (f *FaultDevice) Disconnect(err error) {
+	f.mu.Lock()
+	closeDev := f.disconnect(err)
+	f.mu.Unlock()
+	if closeDev {
+		f.dev.Close()
+	}
+}
+
+// disconnect marks the device disconnected and reports whether the underlying
+// device should be closed. The caller must hold f.mu.
+func (f *FaultDevice) disconnect(err error) bool {
+	if f.err != nil {
+		return false
+	}
+	if err == nil {
+		err = ErrDisconnected
+	}
+	f.err = err
+	return !f.closed
+}
+
+// before determines the faults to apply to an operation of class c. An error
+// is returned if the device has been disconnected.
+func (f *FaultDevice) before(c opClass) (action, error) {
+	var act action
+	f.mu.Lock()
+	if f.err != nil {
+		defer f.mu.Unlock()
+		return act, f.err
+	}
+	for _, s := range f.faults {
+		if !s.Kind.applies(c) {
+			continue
+		}
+		s.ops++
+		if s.ops <= s.After || (s.Count > 0 && s.injected >= s.Count) {
+			continue
+		}
+		if s.Probability > 0 && f.rand.Float64() >= s.Probability {
+			continue
+		}
+		s.injected++
+		f.injected++
+		switch s.Kind {
+		case FaultTimeout:
+			act.timeout = true
+		case FaultShortRead:
+			act.short = true
+		case FaultCorrupt:
+			act.corrupt = true
+		case FaultWriteError:
+			act.err = s.Err
+			if act.err == nil {
+				act.err = ErrInjected
+			}
+		case FaultDisconnect:
+			if f.disconnect(s.Err) {
+				f.mu.Unlock()
+				f.dev.Close()
+				f.mu.Lock()
+			}
+			defer f.mu.Unlock()
+			return act, f.err
+		case FaultLatency:
+			act.delay += s.Latency
+		}
+	}
+	f.mu.Unlock()
+	if act.delay > 0 {
+		time.Sleep(act.delay)
+	}
+	return act, nil
+}
+
+// after returns the error from an operation on the underlying device, or the
+// disconnect error if the device was disconnected during the operation.
+func (f *FaultDevice) after(err error) error {
+	if err == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	return err
+}
+
+// read performs an operation of class c which reads len(p) bytes.
+func (f *FaultDevice) read(c opClass, p []byte, fn func([]byte) (int, error)) (int, error) {
+	act, err := f.before(c)
+	if err != nil {
+		return -1, err
+	}
+	if act.timeout {
+		return 0, hid.ErrTimeout
+	}
+	n, err := fn(p)
+	if err != nil {
+		return n, f.after(err)
+	}
+	if n <= 0 || !(act.short || act.corrupt) {
+		return n, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if act.short && n > 1 {
+		n = 1 + f.rand.Intn(n-1)
+	}
+	if act.corrupt {
+		p[f.rand.Intn(n)] ^= 1 << uint(f.rand.Intn(8))
+	}
+	return n, nil
+}
+
+// write performs an operation which writes len(p) bytes.
+func (f *FaultDevice) write(p []byte, fn func([]byte) (int, error)) (int, error) {
+	act, err := f.before(opWrite)
+	if err != nil {
+		return -1, err
+	}
+	if act.err != nil {
+		return -1, act.err
+	}
+	n, err := fn(p)
+	return n, f.after(err)
+}
+
+// str performs an operation which returns a string.
+func (f *FaultDevice) str(fn func() (string, error)) (string, error) {
+	if _, err := f.before(opOther); err != nil {
+		return "", err
+	}
+	s, err := fn()
+	return s, f.after(err)
+}
+
+// Write writes an output report to the device.
+func (f *FaultDevice) Write(p []byte) (int, error) {
+	return f.write(p, f.dev.Write)
+}
+
+// ReadWithTimeout reads an input report from the device.
+func (f *FaultDevice) ReadWithTimeout(p []byte, timeout time.Duration) (int, error) {
+	return f.read(opRead, p, func(p []byte) (int, error) {
+		return f.dev.ReadWithTimeout(p, timeout)
+	})
+}
+
+// Read reads an input report from the device.
+func (f *FaultDevice) Read(p []byte) (int, error) {
+	return f.read(opRead, p, f.dev.Read)
+}
+
+// SetNonblock sets the nonblocking state of the device.
+func (f *FaultDevice) SetNonblock(nonblocking bool) error {
+	if _, err := f.before(opOther); err != nil {
+		return err
+	}
+	return f.after(f.dev.SetNonblock(nonblocking))
+}
+
+// SendFeatureReport sends a feature report to the device.
+func (f *FaultDevice) SendFeatureReport(p []byte) (int, error) {
+	return f.write(p, f.dev.SendFeatureReport)
+}
+
+// GetFeatureReport gets a feature report from the device.
+func (f *FaultDevice) GetFeatureReport(p []byte) (int, error) {
+	return f.read(opGetReport, p, f.dev.GetFeatureReport)
+}
+
+// GetInputReport gets an input report from the device.
+func (f *FaultDevice) GetInputReport(p []byte) (int, error) {
+	return f.read(opGetReport, p, f.dev.GetInputReport)
+}
+
+// SendOutputReport sends an output report to the device.
+func (f *FaultDevice) SendOutputReport(p []byte) (int, error) {
+	return f.write(p, f.dev.SendOutputReport)
+}
+
+// Close closes the device. Faults are not injected into Close; if the device
+// has been disconnected, Close returns nil.
+func (f *FaultDevice) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return ErrClosed
+	}
+	f.closed = true
+	disconnected := f.err != nil
+	f.mu.Unlock()
+	if disconnected {
+		return nil
+	}
+	return f.dev.Close()
+}
+
+// GetMfrStr returns the manufacturer string of the device.
+func (f *FaultDevice) GetMfrStr() (string, error) {
+	return f.str(f.dev.GetMfrStr)
+}
+
+// GetProductStr returns the product string of the device.
+func (f *FaultDevice) GetProductStr() (string, error) {
+	return f.str(f.dev.GetProductStr)
+}
+
+// GetSerialNbr returns the serial number of the device.
+func (f *FaultDevice) GetSerialNbr() (string, error) {
+	return f.str(f.dev.GetSerialNbr)
+}
+
+// GetIndexedStr returns a string descriptor from the device.
+func (f *FaultDevice) GetIndexedStr(index int) (string, error) {
+	return f.str(func() (string, error) {
+		return f.dev.GetIndexedStr(index)
+	})
+}
+
+// GetDeviceInfo returns information about the device.
+func (f *FaultDevice) GetDeviceInfo() (*hid.DeviceInfo, error) {
+	if _, err := f.before(opOther); err != nil {
+		return nil, err
+	}
+	info, err := f.dev.GetDeviceInfo()
+	return info, f.after(err)
+}
+
+// GetReportDescriptor returns the report descriptor of the device.
+func (f *FaultDevice) GetReportDescriptor(p []byte) (int, error) {
+	if _, err := f.before(opOther); err != nil {
+		return -1, err
+	}
+	n, err := f.dev.GetReportDescriptor(p)
+	return n, f.after(err)
+}
+
+var _ hid.DeviceIO = (*FaultDevice)(nil)
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hidtest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+func TestFaultSchedule(t *testing.T) {
+	d := newTestDevice()
+	f := NewFaultDevice(d, 1, Fault{Kind: FaultWriteError, After: 1, Count: 2})
+	for i, want := range []error{nil, ErrInjected, ErrInjected, nil} {
+		if _, err := f.Write([]byte{0x00, byte(i)}); err != want {
+			t.Errorf("write %d: got %v, want %v", i, err, want)
+		}
+	}
+	if got := len(d.Written()); got != 2 {
+		t.Errorf("got %d reports written, want 2", got)
+	}
+	if got := f.Injected(); got != 2 {
+		t.Errorf("got %d faults injected, want 2", got)
+	}
+}
+
+func TestFaultRead(t *testing.T) {
+	report := []byte{0x01, 0x02, 0x03, 0x04}
+	d := newTestDevice()
+	d.QueueInput(report)
+	d.QueueInput(report)
+	f := NewFaultDevice(d, 1,
+		Fault{Kind: FaultTimeout, Count: 1},
+		Fault{Kind: FaultCorrupt, After: 1, Count: 1},
+		Fault{Kind: FaultShortRead, After: 2})
+
+	b := make([]byte, 65)
+	if _, err := f.ReadWithTimeout(b, time.Second); !errors.Is(err, hid.ErrTimeout) {
+		t.Fatalf("got %v, want %v", err, hid.ErrTimeout)
+	}
+	n, err := f.ReadWithTimeout(b, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(report) || bytes.Equal(b[:n], report) {
+		t.Errorf("got %x, want corrupted %x", b[:n], report)
+	}
+	n, err = f.ReadWithTimeout(b, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n >= len(report) || !bytes.Equal(b[:n], report[:n]) {
+		t.Errorf("got %x, want prefix of %x", b[:n], report)
+	}
+}
+
+func TestFaultDisconnect(t *testing.T) {
+	d := newTestDevice()
+	f := NewFaultDevice(d, 1, Fault{Kind: FaultDisconnect, After: 2})
+	if _, err := f.GetProductStr(); err != nil {
+		t.Fatal(err)
+	}
+
+	errc := make(chan error)
+	go func() {
+		_, err := f.Read(make([]byte, 65))
+		errc <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if _, err := f.Write([]byte{0x00}); err != ErrDisconnected {
+		t.Errorf("got %v, want %v", err, ErrDisconnected)
+	}
+	select {
+	case err := <-errc:
+		if err != ErrDisconnected {
+			t.Errorf("got %v, want %v", err, ErrDisconnected)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("read not interrupted")
+	}
+	if _, err := f.GetMfrStr(); err != ErrDisconnected {
+		t.Errorf("got %v, want %v", err, ErrDisconnected)
+	}
+	if err := f.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFaultLatency(t *testing.T) {
+	f := NewFaultDevice(newTestDevice(), 1, Fault{Kind: FaultLatency, Latency: 20 * time.Millisecond})
+	start := time.Now()
+	if _, err := f.GetMfrStr(); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("got %v, want at least 20ms", elapsed)
+	}
+}
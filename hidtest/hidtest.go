@@ -0,0 +1,539 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+// Package hidtest provides fake HID devices for testing programs that use the
+// hid package without hardware.
+//
+// Programs should accept a hid.DeviceIO or hid.Enumerator rather than calling
+// the hid package directly; tests may then substitute a Device or Enumerator
+// from this package. Devices are configured by setting their fields and
+// scripting responses to output reports:
+//
+//	d := hidtest.NewDevice(hid.DeviceInfo{VendorID: 0x04d8, ProductID: 0x003f})
+//	d.Respond([]byte{0x00, 0x81}, []byte{0x00, 0x81, 0x01})
+//	e := hidtest.NewEnumerator(d)
+//
+// Devices with more complex behavior may be simulated using a Simulator,
+// which is defined by a report descriptor and handlers for each report. A
+// Simulator may also be loaded from a JSON script:
+//
+//	{
+//		"descriptor": "06 00 ff 09 01 a1 01 ... c0",
+//		"features": ["05 01"],
+//		"outputs": [{"id": 2, "reply": [{"report": "03 01", "delay": "5ms"}]}],
+//		"periodic": [{"interval": "100ms", "reports": ["03 00"]}]
+//	}
+//
+// See Script for details.
+//
+// Sessions with real devices may be captured using a Recorder and replayed in
+// tests using a Replayer, which verifies that the program issues the same
+// operations as the recorded session:
+//
+//	f, _ := os.Open("testdata/session.jsonl")
+//	rp, _ := hidtest.NewReplayer(f)
+//	run(rp)
+//	if err := rp.Err(); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// Faults such as timeouts, corrupted reports, and disconnects may be injected
+// into operations on any hid.DeviceIO using a FaultDevice to test error
+// handling and recovery.
+package hidtest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// ErrClosed is returned by operations on a closed Device.
+var ErrClosed = errors.New("device closed")
+
+// response is a scripted response to an output report.
+type response struct {
+	request []byte
+	reports [][]byte
+}
+
+// Device is a fake HID device. The exported fields describe the device and
+// may be modified before the device is used; Device is safe for concurrent
+// use once opened.
+type Device struct {
+	Info             hid.DeviceInfo  // Device Information
+	ReportDescriptor []byte          // Report Descriptor
+	IndexedStrings   map[int]string  // String Descriptors by Index
+	FeatureReports   map[byte][]byte // Feature Reports by Report ID
+	InputReports     map[byte][]byte // Input Reports by Report ID (see GetInputReport)
+
+	// Err, if not nil, is returned by each operation on the device. This
+	// may be used to simulate a device which has been disconnected.
+	Err error
+
+	mu        sync.Mutex
+	ready     chan struct{}
+	done      chan struct{}
+	closed    bool
+	nonblock  bool
+	input     [][]byte
+	written   [][]byte
+	responses []response
+	handler   handler
+}
+
+// handler customizes the behavior of a Device; see Simulator. Methods are
+// called without holding the device lock.
+type handler interface {
+	// output is called for each output report written to the device.
+	output(report []byte)
+
+	// getReport returns the feature or input report with the given ID
+	// and whether the report is handled.
+	getReport(typ hid.ReportType, id byte) ([]byte, bool)
+
+	// setFeature is called for each feature report sent to the device.
+	setFeature(report []byte)
+}
+
+// NewDevice returns a new Device described by info.
+func NewDevice(info hid.DeviceInfo) *Device {
+	return &Device{
+		Info:           info,
+		IndexedStrings: make(map[int]string),
+		FeatureReports: make(map[byte][]byte),
+		InputReports:   make(map[byte][]byte),
+	}
+}
+
+// QueueInput queues input reports to be returned by Read in the order given.
+func (d *Device) QueueInput(reports ...[]byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queue(reports)
+}
+
+// readyChan returns the channel used to wake a blocked Read. The caller must
+// hold d.mu.
+func (d *Device) readyChan() chan struct{} {
+	if d.ready == nil {
+		d.ready = make(chan struct{}, 1)
+	}
+	return d.ready
+}
+
+// doneChan returns the channel closed when the device is closed. The caller
+// must hold d.mu.
+func (d *Device) doneChan() chan struct{} {
+	if d.done == nil {
+		d.done = make(chan struct{})
+	}
+	return d.done
+}
+
+// wake wakes a blocked Read. The caller must hold d.mu.
+func (d *Device) wake() {
+	select {
+	case d.readyChan() <- struct{}{}:
+	default:
+	}
+}
+
+// queue appends reports to the input queue and wakes a blocked Read. The
+// caller must hold d.mu.
+func (d *Device) queue(reports [][]byte) {
+	for _, r := range reports {
+		d.input = append(d.input, append([]byte(nil), r...))
+	}
+	d.wake()
+}
+
+// Respond scripts a response to an output report. When an output report equal
+// to request is written to the device using Write or SendOutputReport, the
+// given reports are queued to be returned by Read. Responses are matched in
+// the order they were added; a response is used once.
+func (d *Device) Respond(request []byte, reports ...[]byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.responses = append(d.responses, response{
+		request: append([]byte(nil), request...),
+		reports: reports,
+	})
+}
+
+// Written returns the output reports written to the device using Write or
+// SendOutputReport.
+func (d *Device) Written() [][]byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([][]byte(nil), d.written...)
+}
+
+// open prepares the device to be returned by an Enumerator.
+func (d *Device) open() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		d.closed, d.done = false, nil
+	}
+}
+
+// check returns an error if the device may not be used. The caller must hold
+// d.mu.
+func (d *Device) check() error {
+	if d.Err != nil {
+		return d.Err
+	}
+	if d.closed {
+		return ErrClosed
+	}
+	return nil
+}
+
+func (d *Device) write(p []byte) (int, error) {
+	d.mu.Lock()
+	if err := d.check(); err != nil {
+		d.mu.Unlock()
+		return -1, err
+	}
+	d.written = append(d.written, append([]byte(nil), p...))
+	for i, r := range d.responses {
+		if bytes.Equal(r.request, p) {
+			d.responses = append(d.responses[:i], d.responses[i+1:]...)
+			d.queue(r.reports)
+			break
+		}
+	}
+	h := d.handler
+	d.mu.Unlock()
+
+	if h != nil && len(p) > 0 {
+		h.output(append([]byte(nil), p...))
+	}
+	return len(p), nil
+}
+
+// Write records an output report and queues any scripted response.
+func (d *Device) Write(p []byte) (int, error) {
+	return d.write(p)
+}
+
+// ReadWithTimeout returns the next queued input report. A negative timeout
+// blocks until a report is queued or the device is closed.
+func (d *Device) ReadWithTimeout(p []byte, timeout time.Duration) (int, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		expired = t.C
+	}
+	for {
+		d.mu.Lock()
+		if err := d.check(); err != nil {
+			d.mu.Unlock()
+			return -1, err
+		}
+		if len(d.input) > 0 {
+			n := copy(p, d.input[0])
+			d.input = d.input[1:]
+			d.mu.Unlock()
+			return n, nil
+		}
+		ready, done := d.readyChan(), d.doneChan()
+		d.mu.Unlock()
+
+		if timeout == 0 {
+			return 0, hid.ErrTimeout
+		}
+		select {
+		case <-ready:
+		case <-done:
+		case <-expired:
+			return 0, hid.ErrTimeout
+		}
+	}
+}
+
+// Read returns the next queued input report. Read blocks unless the device is
+// in nonblocking mode.
+func (d *Device) Read(p []byte) (int, error) {
+	d.mu.Lock()
+	nonblock := d.nonblock
+	d.mu.Unlock()
+	if nonblock {
+		return d.ReadWithTimeout(p, 0)
+	}
+	return d.ReadWithTimeout(p, -1)
+}
+
+// SetNonblock changes the default behavior for Read.
+func (d *Device) SetNonblock(nonblocking bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.check(); err != nil {
+		return err
+	}
+	d.nonblock = nonblocking
+	return nil
+}
+
+// report copies the feature or input report with the ID given by p[0] into p.
+func (d *Device) report(typ hid.ReportType, p []byte) (int, error) {
+	d.mu.Lock()
+	if err := d.check(); err != nil {
+		d.mu.Unlock()
+		return -1, err
+	}
+	if len(p) == 0 {
+		d.mu.Unlock()
+		return -1, errors.New("report ID required")
+	}
+	kind, reports := "input", d.InputReports
+	if typ == hid.FeatureReport {
+		kind, reports = "feature", d.FeatureReports
+	}
+	r, ok := reports[p[0]]
+	h := d.handler
+	d.mu.Unlock()
+
+	if h != nil {
+		if hr, hok := h.getReport(typ, p[0]); hok {
+			r, ok = hr, true
+		}
+	}
+	if !ok {
+		return -1, fmt.Errorf("%s report %d not found", kind, p[0])
+	}
+	return copy(p, r), nil
+}
+
+// SendFeatureReport stores a feature report by report ID; it is returned by
+// subsequent calls to GetFeatureReport.
+func (d *Device) SendFeatureReport(p []byte) (int, error) {
+	d.mu.Lock()
+	if err := d.check(); err != nil {
+		d.mu.Unlock()
+		return -1, err
+	}
+	if len(p) == 0 {
+		d.mu.Unlock()
+		return -1, errors.New("report ID required")
+	}
+	if d.FeatureReports == nil {
+		d.FeatureReports = make(map[byte][]byte)
+	}
+	d.FeatureReports[p[0]] = append([]byte(nil), p...)
+	h := d.handler
+	d.mu.Unlock()
+
+	if h != nil {
+		h.setFeature(append([]byte(nil), p...))
+	}
+	return len(p), nil
+}
+
+// GetFeatureReport returns the feature report with the ID given by p[0].
+func (d *Device) GetFeatureReport(p []byte) (int, error) {
+	return d.report(hid.FeatureReport, p)
+}
+
+// GetInputReport returns the input report with the ID given by p[0].
+func (d *Device) GetInputReport(p []byte) (int, error) {
+	return d.report(hid.InputReport, p)
+}
+
+// SendOutputReport behaves like Write.
+func (d *Device) SendOutputReport(p []byte) (int, error) {
+	return d.write(p)
+}
+
+// Close closes the device and unblocks any pending Read.
+func (d *Device) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+	d.closed = true
+	close(d.doneChan())
+	return nil
+}
+
+// str returns s, or an error if the device may not be used.
+func (d *Device) str(s string) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.check(); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// GetMfrStr returns Info.MfrStr.
+func (d *Device) GetMfrStr() (string, error) {
+	return d.str(d.Info.MfrStr)
+}
+
+// GetProductStr returns Info.ProductStr.
+func (d *Device) GetProductStr() (string, error) {
+	return d.str(d.Info.ProductStr)
+}
+
+// GetSerialNbr returns Info.SerialNbr.
+func (d *Device) GetSerialNbr() (string, error) {
+	return d.str(d.Info.SerialNbr)
+}
+
+// GetIndexedStr returns the string with the given index from IndexedStrings.
+func (d *Device) GetIndexedStr(index int) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.check(); err != nil {
+		return "", err
+	}
+	s, ok := d.IndexedStrings[index]
+	if !ok {
+		return "", fmt.Errorf("string %d not found", index)
+	}
+	return s, nil
+}
+
+// GetDeviceInfo returns a copy of Info.
+func (d *Device) GetDeviceInfo() (*hid.DeviceInfo, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.check(); err != nil {
+		return nil, err
+	}
+	info := d.Info
+	return &info, nil
+}
+
+// GetReportDescriptor returns ReportDescriptor.
+func (d *Device) GetReportDescriptor(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.check(); err != nil {
+		return -1, err
+	}
+	return copy(p, d.ReportDescriptor), nil
+}
+
+var _ hid.DeviceIO = (*Device)(nil)
+
+// Enumerator is a fake source of HID devices.
+type Enumerator struct {
+	mu      sync.Mutex
+	devices []*Device
+}
+
+// NewEnumerator returns a new Enumerator providing the given devices.
+func NewEnumerator(devices ...*Device) *Enumerator {
+	return &Enumerator{devices: devices}
+}
+
+// Add adds a device to the enumerator, simulating a device being attached.
+func (e *Enumerator) Add(d *Device) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.devices = append(e.devices, d)
+}
+
+// Remove removes the device with the given path from the enumerator,
+// simulating a device being detached. The device is closed.
+func (e *Enumerator) Remove(path string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, d := range e.devices {
+		if d.Info.Path == path {
+			d.Close()
+			e.devices = append(e.devices[:i], e.devices[i+1:]...)
+			return
+		}
+	}
+}
+
+// match returns the devices with a matching vendor and product ID.
+func (e *Enumerator) match(vid, pid uint16) []*Device {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var devs []*Device
+	for _, d := range e.devices {
+		if (vid == hid.VendorIDAny || vid == d.Info.VendorID) &&
+			(pid == hid.ProductIDAny || pid == d.Info.ProductID) {
+			devs = append(devs, d)
+		}
+	}
+	return devs
+}
+
+// Enumerate visits each device with a matching vendor and product ID.
+func (e *Enumerator) Enumerate(vid, pid uint16, enumFn hid.EnumFunc) error {
+	for _, d := range e.match(vid, pid) {
+		info := d.Info
+		if err := enumFn(&info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open opens the device with a matching vendor ID, product ID, and serial
+// number.
+func (e *Enumerator) Open(vid, pid uint16, serial string) (hid.DeviceIO, error) {
+	for _, d := range e.match(vid, pid) {
+		if d.Info.VendorID == vid && d.Info.ProductID == pid && d.Info.SerialNbr == serial {
+			d.open()
+			return d, nil
+		}
+	}
+	return nil, errors.New("device not found")
+}
+
+// OpenFirst opens the first device with a matching vendor ID and product ID.
+func (e *Enumerator) OpenFirst(vid, pid uint16) (hid.DeviceIO, error) {
+	for _, d := range e.match(vid, pid) {
+		if d.Info.VendorID == vid && d.Info.ProductID == pid {
+			d.open()
+			return d, nil
+		}
+	}
+	return nil, errors.New("device not found")
+}
+
+// OpenPath opens the device with the given path.
+func (e *Enumerator) OpenPath(path string) (hid.DeviceIO, error) {
+	for _, d := range e.match(hid.VendorIDAny, hid.ProductIDAny) {
+		if d.Info.Path == path {
+			d.open()
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("device %s not found", path)
+}
+
+var _ hid.Enumerator = (*Enumerator)(nil)
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hidtest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+func newTestDevice() *Device {
+	return NewDevice(hid.DeviceInfo{
+		Path:       "/dev/hidraw0",
+		VendorID:   0x04d8,
+		ProductID:  0x003f,
+		SerialNbr:  "0001",
+		MfrStr:     "Microchip Technology Inc.",
+		ProductStr: "Simple HID Device Demo",
+	})
+}
+
+func TestRespond(t *testing.T) {
+	d := newTestDevice()
+	d.Respond([]byte{0x00, 0x81}, []byte{0x00, 0x81, 0x01})
+
+	if _, err := d.Write([]byte{0x00, 0x80}); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 65)
+	if _, err := d.ReadWithTimeout(b, 0); !errors.Is(err, hid.ErrTimeout) {
+		t.Errorf("got %v, want %v", err, hid.ErrTimeout)
+	}
+
+	if _, err := d.Write([]byte{0x00, 0x81}); err != nil {
+		t.Fatal(err)
+	}
+	n, err := d.ReadWithTimeout(b, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x00, 0x81, 0x01}; !bytes.Equal(b[:n], want) {
+		t.Errorf("got %x, want %x", b[:n], want)
+	}
+	if got := d.Written(); len(got) != 2 {
+		t.Errorf("got %d reports written, want 2", len(got))
+	}
+}
+
+func TestReadClose(t *testing.T) {
+	d := newTestDevice()
+	errc := make(chan error)
+	go func() {
+		_, err := d.Read(make([]byte, 65))
+		errc <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	d.Close()
+	if err := <-errc; !errors.Is(err, ErrClosed) {
+		t.Errorf("got %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestFeatureReport(t *testing.T) {
+	d := newTestDevice()
+	if _, err := d.GetFeatureReport([]byte{0x01, 0x00}); err == nil {
+		t.Error("expected error for missing report")
+	}
+	if _, err := d.SendFeatureReport([]byte{0x01, 0xaa}); err != nil {
+		t.Fatal(err)
+	}
+	b := []byte{0x01, 0x00}
+	if _, err := d.GetFeatureReport(b); err != nil {
+		t.Fatal(err)
+	}
+	if b[1] != 0xaa {
+		t.Errorf("got %#02x, want 0xaa", b[1])
+	}
+}
+
+func TestEnumerator(t *testing.T) {
+	d := newTestDevice()
+	e := NewEnumerator(d, NewDevice(hid.DeviceInfo{Path: "/dev/hidraw1", VendorID: 0x1234}))
+
+	var paths []string
+	e.Enumerate(0x04d8, hid.ProductIDAny, func(info *hid.DeviceInfo) error {
+		paths = append(paths, info.Path)
+		return nil
+	})
+	if len(paths) != 1 || paths[0] != "/dev/hidraw0" {
+		t.Errorf("got %v, want [/dev/hidraw0]", paths)
+	}
+
+	dev, err := e.Open(0x04d8, 0x003f, "0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := dev.GetProductStr(); s != d.Info.ProductStr {
+		t.Errorf("got %q, want %q", s, d.Info.ProductStr)
+	}
+	if _, err := e.Open(0x04d8, 0x003f, "0002"); err == nil {
+		t.Error("expected error for serial number mismatch")
+	}
+
+	e.Remove("/dev/hidraw0")
+	if _, err := dev.GetProductStr(); !errors.Is(err, ErrClosed) {
+		t.Errorf("got %v, want %v", err, ErrClosed)
+	}
+	if _, err := e.OpenPath("/dev/hidraw0"); err == nil {
+		t.Error("expected error for removed device")
+	}
+}
+
+func TestErr(t *testing.T) {
+	d := newTestDevice()
+	d.Err = errors.New("disconnected")
+	if _, err := d.Write([]byte{0x00}); err != d.Err {
+		t.Errorf("got %v, want %v", err, d.Err)
+	}
+}
@@ -0,0 +1,586 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hidtest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// sessionFormat identifies the file format written by Recorder.
+const sessionFormat = "go-hid-session"
+
+// sessionHeader is the first line of a session file.
+type sessionHeader struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+}
+
+// op describes an operation recorded in a session file.
+type op struct {
+	Seq      int             `json:"seq"`                // Sequence Number
+	Time     Duration        `json:"time"`               // Time Since Start of Session
+	Duration Duration        `json:"duration,omitempty"` // Duration of Operation
+	Op       string          `json:"op"`                 // Method Name
+	Arg      Bytes           `json:"arg,omitempty"`      // Data Passed to Operation
+	Index    int             `json:"index,omitempty"`    // String Index or Nonblocking Flag
+	Timeout  Duration        `json:"timeout,omitempty"`  // Read Timeout
+	Len      int             `json:"len,omitempty"`      // Length of Report Buffer
+	N        int             `json:"n,omitempty"`        // Number of Bytes Read or Written
+	Data     Bytes           `json:"data,omitempty"`     // Data Returned by Operation
+	Str      string          `json:"str,omitempty"`      // String Returned by Operation
+	Info     *hid.DeviceInfo `json:"info,omitempty"`     // Device Information
+	Err      string          `json:"err,omitempty"`      // Error Returned by Operation
+}
+
+// isGetReport reports whether the operation named name requests a report from
+// the device. Only the report ID (the first byte) and length of the buffer
+// passed to these operations are meaningful; the remainder of the buffer is
+// not recorded.
+func isGetReport(name string) bool {
+	return name == "GetFeatureReport" || name == "GetInputReport"
+}
+
+// isRead reports whether the operation reads input reports; reads are
+// replayed independently of other operations.
+func (o *op) isRead() bool {
+	return o.Op == "Read" || o.Op == "ReadWithTimeout"
+}
+
+// error returns the error recorded by the operation. Timeouts are returned as
+// hid.ErrTimeout.
+func (o *op) error() error {
+	switch o.Err {
+	case "":
+		return nil
+	case hid.ErrTimeout.Error():
+		return hid.ErrTimeout
+	}
+	return errors.New(o.Err)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// Recorder is a hid.DeviceIO which records each operation on a device to a
+// session file, which may be replayed by a Replayer. Session files contain a
+// header followed by one JSON object per operation (JSON Lines), which
+// includes the data passed to and returned by the operation and its timing.
+//
+// Recorder does not depend on the remainder of this package and may be used
+// outside of tests, for example to capture a session in the field.
+type Recorder struct {
+	dev   hid.DeviceIO
+	start time.Time
+
+	mu  sync.Mutex
+	enc *json.Encoder
+	seq int
+	err error
+}
+
+// NewRecorder returns a new Recorder which records operations on dev to w.
+func NewRecorder(dev hid.DeviceIO, w io.Writer) (*Recorder, error) {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(sessionHeader{sessionFormat, 1}); err != nil {
+		return nil, err
+	}
+	return &Recorder{dev: dev, start: time.Now(), enc: enc}, nil
+}
+
+// Err returns the first error that occurred writing the session file.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// record writes o to the session file. The operation began at start.
+func (r *Recorder) record(start time.Time, o op) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o.Seq = r.seq
+	o.Time = Duration(start.Sub(r.start))
+	o.Duration = Duration(time.Since(start))
+	r.seq++
+	if err := r.enc.Encode(&o); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// recordIO records an operation which reads or writes len(p) bytes.
+func (r *Recorder) recordIO(name string, p []byte, read bool, fn func([]byte) (int, error)) (int, error) {
+	start := time.Now()
+	o := op{Op: name}
+	switch {
+	case !read:
+		o.Arg = append(Bytes(nil), p...)
+	case isGetReport(name):
+		o.Arg, o.Len = reportArg(p), len(p)
+	}
+	n, err := fn(p)
+	o.N, o.Err = n, errString(err)
+	if read && n > 0 {
+		o.Data = append(Bytes(nil), p[:n]...)
+	}
+	r.record(start, o)
+	return n, err
+}
+
+// reportArg returns the report ID passed to an operation which requests a
+// report from the device.
+func reportArg(p []byte) Bytes {
+	if len(p) == 0 {
+		return nil
+	}
+	return Bytes{p[0]}
+}
+
+// recordStr records an operation which returns a string.
+func (r *Recorder) recordStr(name string, index int, fn func() (string, error)) (string, error) {
+	start := time.Now()
+	s, err := fn()
+	r.record(start, op{Op: name, Index: index, Str: s, Err: errString(err)})
+	return s, err
+}
+
+// Write records a call to Write.
+func (r *Recorder) Write(p []byte) (int, error) {
+	return r.recordIO("Write", p, false, r.dev.Write)
+}
+
+// ReadWithTimeout records a call to ReadWithTimeout.
+func (r *Recorder) ReadWithTimeout(p []byte, timeout time.Duration) (int, error) {
+	start := time.Now()
+	n, err := r.dev.ReadWithTimeout(p, timeout)
+	o := op{Op: "ReadWithTimeout", Timeout: Duration(timeout), N: n, Err: errString(err)}
+	if n > 0 {
+		o.Data = append(Bytes(nil), p[:n]...)
+	}
+	r.record(start, o)
+	return n, err
+}
+
+// Read records a call to Read.
+func (r *Recorder) Read(p []byte) (int, error) {
+	return r.recordIO("Read", p, true, r.dev.Read)
+}
+
+// SetNonblock records a call to SetNonblock.
+func (r *Recorder) SetNonblock(nonblocking bool) error {
+	start := time.Now()
+	err := r.dev.SetNonblock(nonblocking)
+	o := op{Op: "SetNonblock", Err: errString(err)}
+	if nonblocking {
+		o.Index = 1
+	}
+	r.record(start, o)
+	return err
+}
+
+// SendFeatureReport records a call to SendFeatureReport.
+func (r *Recorder) SendFeatureReport(p []byte) (int, error) {
+	return r.recordIO("SendFeatureReport", p, false, r.dev.SendFeatureReport)
+}
+
+// GetFeatureReport records a call to GetFeatureReport.
+func (r *Recorder) GetFeatureReport(p []byte) (int, error) {
+	return r.recordIO("GetFeatureReport", p, true, r.dev.GetFeatureReport)
+}
+
+// GetInputReport records a call to GetInputReport.
+func (r *Recorder) GetInputReport(p []byte) (int, error) {
+	return r.recordIO("GetInputReport", p, true, r.dev.GetInputReport)
+}
+
+// SendOutputReport records a call to SendOutputReport.
+func (r *Recorder) SendOutputReport(p []byte) (int, error) {
+	return r.recordIO("SendOutputReport", p, false, r.dev.SendOutputReport)
+}
+
+// Close records a call to Close.
+func (r *Recorder) Close() error {
+	start := time.Now()
+	err := r.dev.Close()
+	r.record(start, op{Op: "Close", Err: errString(err)})
+	return err
+}
+
+// GetMfrStr records a call to GetMfrStr.
+func (r *Recorder) GetMfrStr() (string, error) {
+	return r.recordStr("GetMfrStr", 0, r.dev.GetMfrStr)
+}
+
+// GetProductStr records a call to GetProductStr.
+func (r *Recorder) GetProductStr() (string, error) {
+	return r.recordStr("GetProductStr", 0, r.dev.GetProductStr)
+}
+
+// GetSerialNbr records a call to GetSerialNbr.
+func (r *Recorder) GetSerialNbr() (string, error) {
+	return r.recordStr("GetSerialNbr", 0, r.dev.GetSerialNbr)
+}
+
+// GetIndexedStr records a call to GetIndexedStr.
+func (r *Recorder) GetIndexedStr(index int) (string, error) {
+	return r.recordStr("GetIndexedStr", index, func() (string, error) {
+		return r.dev.GetIndexedStr(index)
+	})
+}
+
+// GetDeviceInfo records a call to GetDeviceInfo.
+func (r *Recorder) GetDeviceInfo() (*hid.DeviceInfo, error) {
+	start := time.Now()
+	info, err := r.dev.GetDeviceInfo()
+	r.record(start, op{Op: "GetDeviceInfo", Info: info, Err: errString(err)})
+	return info, err
+}
+
+// GetReportDescriptor records a call to GetReportDescriptor.
+func (r *Recorder) GetReportDescriptor(p []byte) (int, error) {
+	return r.recordIO("GetReportDescriptor", p, true, r.dev.GetReportDescriptor)
+}
+
+var _ hid.DeviceIO = (*Recorder)(nil)
+
+// ErrEndOfSession is returned by a Replayer once all recorded operations have
+// been replayed.
+var ErrEndOfSession = errors.New("end of session")
+
+// MismatchError is returned by a Replayer if an operation differs from the
+// operation recorded in the session.
+type MismatchError struct {
+	Seq  int    // Sequence Number of Recorded Operation
+	Want string // Recorded Operation
+	Got  string // Replayed Operation
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("operation %d: got %s, want %s", e.Seq, e.Got, e.Want)
+}
+
+// Replayer is a hid.DeviceIO which replays a session recorded by a Recorder.
+// Each operation must match the operation recorded in the session; data
+// passed to operations which write to the device must be identical, and
+// requests for feature and input reports must pass the same report ID and
+// buffer length. Results,
+// including errors, are returned as recorded.
+//
+// Reads are replayed independently of other operations, which allows input
+// reports to be read from a separate goroutine. A read does not complete until
+// the operations recorded before it have been replayed.
+type Replayer struct {
+	// Realtime, if true, delays each operation until the time it was
+	// recorded relative to the start of the session.
+	Realtime bool
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	start   time.Time
+	ops     []*op // operations other than reads
+	reads   []*op
+	replays int // sequence numbers below this have been replayed
+	pending map[int]bool
+	err     error
+}
+
+// NewReplayer returns a new Replayer which replays the session read from r.
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+
+	var h sessionHeader
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("missing session header")
+	}
+	if err := json.Unmarshal(sc.Bytes(), &h); err != nil || h.Format != sessionFormat {
+		return nil, errors.New("invalid session header")
+	}
+	if h.Version != 1 {
+		return nil, fmt.Errorf("unsupported session version: %d", h.Version)
+	}
+
+	rp := &Replayer{pending: make(map[int]bool)}
+	rp.cond = sync.NewCond(&rp.mu)
+	for sc.Scan() {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		o := new(op)
+		if err := json.Unmarshal(sc.Bytes(), o); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", len(rp.ops)+len(rp.reads), err)
+		}
+		if o.isRead() {
+			rp.reads = append(rp.reads, o)
+		} else {
+			rp.ops = append(rp.ops, o)
+		}
+		rp.pending[o.Seq] = true
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// Err returns the first mismatch that occurred during replay.
+func (rp *Replayer) Err() error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	return rp.err
+}
+
+// Remaining returns the number of recorded operations which have not been
+// replayed.
+func (rp *Replayer) Remaining() int {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	return len(rp.pending)
+}
+
+// next returns the next recorded operation from queue once all operations
+// recorded before it have been replayed. The caller must hold rp.mu.
+func (rp *Replayer) next(queue *[]*op, wait bool) (*op, error) {
+	if rp.start.IsZero() {
+		rp.start = time.Now()
+	}
+	for {
+		if rp.err != nil {
+			return nil, rp.err
+		}
+		if len(*queue) == 0 {
+			return nil, ErrEndOfSession
+		}
+		o := (*queue)[0]
+		if !wait || rp.ready(o.Seq) {
+			*queue = (*queue)[1:]
+			return o, nil
+		}
+		rp.cond.Wait()
+	}
+}
+
+// ready reports whether all operations recorded before seq have been
+// replayed. The caller must hold rp.mu.
+func (rp *Replayer) ready(seq int) bool {
+	for s := range rp.pending {
+		if s < seq {
+			return false
+		}
+	}
+	return true
+}
+
+// done marks o as replayed and delays until the time it was recorded if
+// Realtime is set. The caller must hold rp.mu; it is released while waiting.
+func (rp *Replayer) done(o *op) {
+	if rp.Realtime {
+		if d := time.Until(rp.start.Add(time.Duration(o.Time + o.Duration))); d > 0 {
+			rp.mu.Unlock()
+			time.Sleep(d)
+			rp.mu.Lock()
+		}
+	}
+	delete(rp.pending, o.Seq)
+	rp.cond.Broadcast()
+}
+
+// mismatch records a mismatch for the operation o and returns it.
+func (rp *Replayer) mismatch(o *op, got string) error {
+	want := opString(o.Op, o.Arg, o.Len)
+	if rp.err == nil {
+		rp.err = &MismatchError{Seq: o.Seq, Want: want, Got: got}
+	}
+	rp.cond.Broadcast()
+	return rp.err
+}
+
+// opString returns a description of the operation name with argument arg and
+// buffer length n, if non-zero.
+func opString(name string, arg []byte, n int) string {
+	if n != 0 {
+		return fmt.Sprintf("%s(% x, len %d)", name, arg, n)
+	}
+	return fmt.Sprintf("%s(% x)", name, arg)
+}
+
+// replay replays the operation name with argument arg and buffer length n.
+func (rp *Replayer) replay(name string, arg []byte, n, index int) (*op, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	o, err := rp.next(&rp.ops, true)
+	if err != nil {
+		return nil, err
+	}
+	if o.Op != name || !bytes.Equal(o.Arg, arg) || o.Len != n || o.Index != index {
+		return nil, rp.mismatch(o, opString(name, arg, n))
+	}
+	rp.done(o)
+	return o, nil
+}
+
+// replayIO replays an operation which reads or writes len(p) bytes.
+func (rp *Replayer) replayIO(name string, p []byte, read bool) (int, error) {
+	var arg []byte
+	var n int
+	switch {
+	case !read:
+		arg = p
+	case isGetReport(name):
+		arg, n = reportArg(p), len(p)
+	}
+	o, err := rp.replay(name, arg, n, 0)
+	if err != nil {
+		return -1, err
+	}
+	if read {
+		copy(p, o.Data)
+	}
+	return o.N, o.error()
+}
+
+// replayStr replays an operation which returns a string.
+func (rp *Replayer) replayStr(name string, index int) (string, error) {
+	o, err := rp.replay(name, nil, 0, index)
+	if err != nil {
+		return "", err
+	}
+	return o.Str, o.error()
+}
+
+// Write replays a call to Write.
+func (rp *Replayer) Write(p []byte) (int, error) {
+	return rp.replayIO("Write", p, false)
+}
+
+// ReadWithTimeout replays a recorded read.
+func (rp *Replayer) ReadWithTimeout(p []byte, timeout time.Duration) (int, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	o, err := rp.next(&rp.reads, true)
+	if err != nil {
+		return -1, err
+	}
+	rp.done(o)
+	copy(p, o.Data)
+	return o.N, o.error()
+}
+
+// Read replays a recorded read.
+func (rp *Replayer) Read(p []byte) (int, error) {
+	return rp.ReadWithTimeout(p, -1)
+}
+
+// SetNonblock replays a call to SetNonblock.
+func (rp *Replayer) SetNonblock(nonblocking bool) error {
+	index := 0
+	if nonblocking {
+		index = 1
+	}
+	o, err := rp.replay("SetNonblock", nil, 0, index)
+	if err != nil {
+		return err
+	}
+	return o.error()
+}
+
+// SendFeatureReport replays a call to SendFeatureReport.
+func (rp *Replayer) SendFeatureReport(p []byte) (int, error) {
+	return rp.replayIO("SendFeatureReport", p, false)
+}
+
+// GetFeatureReport replays a call to GetFeatureReport.
+func (rp *Replayer) GetFeatureReport(p []byte) (int, error) {
+	return rp.replayIO("GetFeatureReport", p, true)
+}
+
+// GetInputReport replays a call to GetInputReport.
+func (rp *Replayer) GetInputReport(p []byte) (int, error) {
+	return rp.replayIO("GetInputReport", p, true)
+}
+
+// SendOutputReport replays a call to SendOutputReport.
+func (rp *Replayer) SendOutputReport(p []byte) (int, error) {
+	return rp.replayIO("SendOutputReport", p, false)
+}
+
+// Close replays a call to Close.
+func (rp *Replayer) Close() error {
+	o, err := rp.replay("Close", nil, 0, 0)
+	if err != nil {
+		return err
+	}
+	return o.error()
+}
+
+// GetMfrStr replays a call to GetMfrStr.
+func (rp *Replayer) GetMfrStr() (string, error) {
+	return rp.replayStr("GetMfrStr", 0)
+}
+
+// GetProductStr replays a call to GetProductStr.
+func (rp *Replayer) GetProductStr() (string, error) {
+	return rp.replayStr("GetProductStr", 0)
+}
+
+// GetSerialNbr replays a call to GetSerialNbr.
+func (rp *Replayer) GetSerialNbr() (string, error) {
+	return rp.replayStr("GetSerialNbr", 0)
+}
+
+// GetIndexedStr replays a call to GetIndexedStr.
+func (rp *Replayer) GetIndexedStr(index int) (string, error) {
+	return rp.replayStr("GetIndexedStr", index)
+}
+
+// GetDeviceInfo replays a call to GetDeviceInfo.
+func (rp *Replayer) GetDeviceInfo() (*hid.DeviceInfo, error) {
+	o, err := rp.replay("GetDeviceInfo", nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return o.Info, o.error()
+}
+
+// GetReportDescriptor replays a call to GetReportDescriptor.
+func (rp *Replayer) GetReportDescriptor(p []byte) (int, error) {
+	return rp.replayIO("GetReportDescriptor", p, true)
+}
+
+var _ hid.DeviceIO = (*Replayer)(nil)
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hidtest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// session exercises dev as an application would, reading input reports from
+// a separate goroutine.
+func session(dev hid.DeviceIO, cmd byte) ([]byte, string, error) {
+	str, err := dev.GetProductStr()
+	if err != nil {
+		return nil, "", err
+	}
+	type result struct {
+		b   []byte
+		err error
+	}
+	resc := make(chan result, 1)
+	go func() {
+		b := make([]byte, 65)
+		n, err := dev.ReadWithTimeout(b, time.Second)
+		if err != nil {
+			resc <- result{nil, err}
+			return
+		}
+		resc <- result{b[:n], nil}
+	}()
+	if _, err := dev.Write([]byte{0x00, cmd}); err != nil {
+		return nil, "", err
+	}
+	res := <-resc
+	if res.err != nil {
+		return nil, "", res.err
+	}
+	return res.b, str, dev.Close()
+}
+
+func record(t *testing.T) []byte {
+	t.Helper()
+	d := newTestDevice()
+	d.Respond([]byte{0x00, 0x81}, []byte{0x00, 0x81, 0x01})
+
+	var buf bytes.Buffer
+	r, err := NewRecorder(d, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := session(r, 0x81); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReplay(t *testing.T) {
+	rp, err := NewReplayer(bytes.NewReader(record(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, str, err := session(rp, 0x81)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x00, 0x81, 0x01}; !bytes.Equal(b, want) {
+		t.Errorf("got %x, want %x", b, want)
+	}
+	if want := "Simple HID Device Demo"; str != want {
+		t.Errorf("got %q, want %q", str, want)
+	}
+	if err := rp.Err(); err != nil {
+		t.Error(err)
+	}
+	if n := rp.Remaining(); n != 0 {
+		t.Errorf("got %d operations remaining, want 0", n)
+	}
+	if _, err := rp.Write([]byte{0x00}); !errors.Is(err, ErrEndOfSession) {
+		t.Errorf("got %v, want %v", err, ErrEndOfSession)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	rp, err := NewReplayer(bytes.NewReader(record(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = session(rp, 0x80)
+	var merr *MismatchError
+	if !errors.As(err, &merr) {
+		t.Fatalf("got %v, want *MismatchError", err)
+	}
+	if want := "Write(00 81)"; merr.Want != want {
+		t.Errorf("got %q, want %q", merr.Want, want)
+	}
+	if rp.Err() != err {
+		t.Errorf("got %v, want %v", rp.Err(), err)
+	}
+}
+
+func TestReplayGetReport(t *testing.T) {
+	d := newTestDevice()
+	d.FeatureReports[0x03] = []byte{0x03, 0x01, 0x02}
+	var buf bytes.Buffer
+	r, err := NewRecorder(d, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := []byte{0x03, 0xde, 0xad, 0xbe, 0xef}
+	if _, err := r.GetFeatureReport(b); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"arg":"03","len":5`)) {
+		t.Errorf("session records unused buffer contents: %s", buf.Bytes())
+	}
+
+	rp, err := NewReplayer(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b = []byte{0x03, 0xff, 0xff, 0xff, 0xff} // reused buffer
+	n, err := rp.GetFeatureReport(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x03, 0x01, 0x02}; !bytes.Equal(b[:n], want) {
+		t.Errorf("got %x, want %x", b[:n], want)
+	}
+
+	rp, err = NewReplayer(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = rp.GetFeatureReport(make([]byte, 8))
+	var merr *MismatchError
+	if !errors.As(err, &merr) {
+		t.Fatalf("got %v, want *MismatchError", err)
+	}
+	if want := "GetFeatureReport(03, len 5)"; merr.Want != want {
+		t.Errorf("got %q, want %q", merr.Want, want)
+	}
+}
+
+func TestReplayTimeout(t *testing.T) {
+	d := newTestDevice()
+	var buf bytes.Buffer
+	r, err := NewRecorder(d, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 65)
+	if _, err := r.ReadWithTimeout(b, 0); !errors.Is(err, hid.ErrTimeout) {
+		t.Fatalf("got %v, want %v", err, hid.ErrTimeout)
+	}
+
+	rp, err := NewReplayer(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rp.ReadWithTimeout(b, 0); !errors.Is(err, hid.ErrTimeout) {
+		t.Errorf("got %v, want %v", err, hid.ErrTimeout)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hidtest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// Bytes is a byte slice encoded in JSON as a string of hexadecimal digits.
+// Digits may be separated by white space (e.g. "05 01 ff").
+type Bytes []byte
+
+// MarshalJSON implements the json.Marshaler interface.
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	s := make([]string, len(b))
+	for i, c := range b {
+		s[i] = fmt.Sprintf("%02x", c)
+	}
+	return json.Marshal(strings.Join(s, " "))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		return fmt.Errorf("invalid bytes: %q", s)
+	}
+	*b = v
+	return nil
+}
+
+// Duration is a time.Duration encoded in JSON as a string (e.g. "5ms").
+type Duration time.Duration
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Script declares the behavior of a Simulator. Reports exchanged with the
+// device begin with the report ID (0 for devices which only support a single
+// report), with the exception of input reports sent by devices which only
+// support a single report.
+type Script struct {
+	Info       hid.DeviceInfo `json:"info"`               // Device Information
+	Descriptor Bytes          `json:"descriptor"`         // Report Descriptor
+	Strings    map[int]string `json:"strings,omitempty"`  // String Descriptors by Index
+	Features   []Bytes        `json:"features,omitempty"` // Initial Feature Reports
+	Inputs     []Bytes        `json:"inputs,omitempty"`   // Reports Returned by GetInputReport
+	Outputs    []OutputRule   `json:"outputs,omitempty"`  // Responses to Output Reports
+	Periodic   []PeriodicRule `json:"periodic,omitempty"` // Periodic Input Reports
+}
+
+// OutputRule describes the response to an output report.
+type OutputRule struct {
+	ID    byte    `json:"id"`              // Report ID
+	Match Bytes   `json:"match,omitempty"` // Prefix Matched Against Report (Optional)
+	Reply []Reply `json:"reply"`           // Input Reports to Queue
+}
+
+// Reply describes an input report queued in response to an output report.
+type Reply struct {
+	Report Bytes    `json:"report"`          // Input Report
+	Delay  Duration `json:"delay,omitempty"` // Delay Before Queueing Report
+}
+
+// PeriodicRule describes input reports queued at a fixed interval. Reports
+// are queued in order, repeating from the first report once exhausted.
+type PeriodicRule struct {
+	Interval Duration `json:"interval"` // Interval Between Reports
+	Reports  []Bytes  `json:"reports"`  // Input Reports to Queue
+}
+
+// ParseScript parses a JSON script from r.
+func ParseScript(r io.Reader) (*Script, error) {
+	var sc Script
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&sc); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+// LoadScript parses the JSON script in the named file.
+func LoadScript(name string) (*Script, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sc, err := ParseScript(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return sc, nil
+}
+
+// Simulator returns a new Simulator with the behavior declared by the script.
+// Periodic input reports are emitted once the simulator is started.
+func (sc *Script) Simulator() (*Simulator, error) {
+	s, err := NewSimulator(sc.Info, sc.Descriptor)
+	if err != nil {
+		return nil, err
+	}
+	for index, str := range sc.Strings {
+		s.IndexedStrings[index] = str
+	}
+	for _, r := range sc.Features {
+		if len(r) == 0 {
+			return nil, fmt.Errorf("feature report: report ID required")
+		}
+		s.FeatureReports[r[0]] = s.pad(hid.FeatureReport, r, true)
+	}
+	for _, r := range sc.Inputs {
+		if len(r) == 0 {
+			return nil, fmt.Errorf("input report: report ID required")
+		}
+		s.InputReports[r[0]] = s.pad(hid.InputReport, r, true)
+	}
+
+	rules := make(map[byte][]OutputRule)
+	for _, rule := range sc.Outputs {
+		rules[rule.ID] = append(rules[rule.ID], rule)
+	}
+	for id, rules := range rules {
+		rules := rules
+		s.OnOutput(id, func(report []byte) {
+			for _, rule := range rules {
+				if bytes.HasPrefix(report, rule.Match) {
+					for _, reply := range rule.Reply {
+						s.SendAfter(time.Duration(reply.Delay), reply.Report)
+					}
+					return
+				}
+			}
+		})
+	}
+
+	for _, rule := range sc.Periodic {
+		if rule.Interval <= 0 || len(rule.Reports) == 0 {
+			return nil, fmt.Errorf("periodic: interval and reports required")
+		}
+		var n int
+		reports := rule.Reports
+		s.Every(time.Duration(rule.Interval), func() []byte {
+			r := reports[n%len(reports)]
+			n++
+			return r
+		})
+	}
+	return s, nil
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hidtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// Simulator is a fake HID device whose behavior is defined by a report
+// descriptor and handlers. Handlers are registered by report ID; devices which
+// only support a single report use a report ID of 0. Reports are padded with
+// zeros to the size given by the report descriptor.
+//
+// A Simulator may be declared in Go or loaded from a Script.
+type Simulator struct {
+	*Device
+
+	sizes hid.ReportSizes
+
+	mu           sync.Mutex
+	onGetFeature map[byte]func() []byte
+	onSetFeature map[byte]func(report []byte)
+	onGetInput   map[byte]func() []byte
+	onOutput     map[byte]func(report []byte)
+	periodic     []periodic
+	stop         chan struct{}
+	wg           sync.WaitGroup
+}
+
+// periodic describes input reports emitted at a fixed interval.
+type periodic struct {
+	interval time.Duration
+	fn       func() []byte
+}
+
+// NewSimulator returns a new Simulator described by info with the report
+// descriptor desc. If info does not describe any collections, they are parsed
+// from desc.
+func NewSimulator(info hid.DeviceInfo, desc []byte) (*Simulator, error) {
+	sizes, err := hid.ParseReportSizes(desc)
+	if err != nil {
+		return nil, err
+	}
+	if info.Collections == nil {
+		info.Collections, _ = hid.ParseCollections(desc)
+	}
+
+	s := &Simulator{
+		Device:       NewDevice(info),
+		sizes:        sizes,
+		onGetFeature: make(map[byte]func() []byte),
+		onSetFeature: make(map[byte]func([]byte)),
+		onGetInput:   make(map[byte]func() []byte),
+		onOutput:     make(map[byte]func([]byte)),
+	}
+	s.ReportDescriptor = append([]byte(nil), desc...)
+	s.Device.handler = s
+	return s, nil
+}
+
+// pad returns report padded with zeros to the size of the report of type typ.
+// If prefixed is true, the report begins with a report ID, which is included
+// in the size.
+func (s *Simulator) pad(typ hid.ReportType, report []byte, prefixed bool) []byte {
+	var id byte
+	if s.sizes.Numbered() && len(report) > 0 {
+		id = report[0]
+	}
+	n, ok := s.sizes.Size(typ, id)
+	if !ok {
+		return report
+	}
+	if prefixed {
+		n++
+	}
+	if len(report) >= n {
+		return report
+	}
+	return append(append(make([]byte, 0, n), report...), make([]byte, n-len(report))...)
+}
+
+// OnGetFeature registers fn to return the feature report with the given ID.
+// The report returned by fn must begin with the report ID.
+func (s *Simulator) OnGetFeature(id byte, fn func() []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onGetFeature[id] = fn
+}
+
+// OnSetFeature registers fn to be called when a feature report with the given
+// ID is sent to the device.
+func (s *Simulator) OnSetFeature(id byte, fn func(report []byte)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onSetFeature[id] = fn
+}
+
+// OnGetInput registers fn to return the input report with the given ID from
+// GetInputReport. The report returned by fn must begin with the report ID.
+func (s *Simulator) OnGetInput(id byte, fn func() []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onGetInput[id] = fn
+}
+
+// OnOutput registers fn to be called when an output report with the given ID
+// is written to the device. The report passed to fn begins with the report ID.
+func (s *Simulator) OnOutput(id byte, fn func(report []byte)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onOutput[id] = fn
+}
+
+// Send queues an input report to be returned by Read. For devices which
+// support multiple reports, the report must begin with the report ID.
+func (s *Simulator) Send(report []byte) {
+	s.QueueInput(s.pad(hid.InputReport, report, s.sizes.Numbered()))
+}
+
+// SendAfter queues an input report to be returned by Read after delay.
+func (s *Simulator) SendAfter(delay time.Duration, report []byte) {
+	if delay <= 0 {
+		s.Send(report)
+		return
+	}
+	report = append([]byte(nil), report...)
+	time.AfterFunc(delay, func() { s.Send(report) })
+}
+
+// Every registers fn to return an input report to be queued at each interval
+// while the simulator is running. If fn returns nil, no report is queued.
+func (s *Simulator) Every(interval time.Duration, fn func() []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.periodic = append(s.periodic, periodic{interval, fn})
+}
+
+// Start starts emitting the periodic input reports registered by Every.
+func (s *Simulator) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	for _, p := range s.periodic {
+		s.wg.Add(1)
+		go s.run(p, s.stop)
+	}
+}
+
+func (s *Simulator) run(p periodic, stop <-chan struct{}) {
+	defer s.wg.Done()
+	t := time.NewTicker(p.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if report := p.fn(); report != nil {
+				s.Send(report)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Stop stops emitting periodic input reports.
+func (s *Simulator) Stop() {
+	s.mu.Lock()
+	stop := s.stop
+	s.stop = nil
+	s.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		s.wg.Wait()
+	}
+}
+
+func (s *Simulator) handle(m map[byte]func(report []byte), report []byte) {
+	s.mu.Lock()
+	fn := m[report[0]]
+	s.mu.Unlock()
+	if fn != nil {
+		fn(report)
+	}
+}
+
+func (s *Simulator) output(report []byte) {
+	s.handle(s.onOutput, report)
+}
+
+func (s *Simulator) setFeature(report []byte) {
+	s.handle(s.onSetFeature, report)
+}
+
+func (s *Simulator) getReport(typ hid.ReportType, id byte) ([]byte, bool) {
+	s.mu.Lock()
+	fn := s.onGetInput[id]
+	if typ == hid.FeatureReport {
+		fn = s.onGetFeature[id]
+	}
+	s.mu.Unlock()
+	if fn == nil {
+		return nil, false
+	}
+	return s.pad(typ, fn(), true), true
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hidtest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// testDesc describes a vendor-defined device with a 63-byte output report
+// (ID 2), a 16-byte input report (ID 3), and a 2-byte feature report (ID 5).
+var testDesc = []byte{
+	0x06, 0x00, 0xff, // Usage Page (Vendor Defined 0xFF00)
+	0x09, 0x01, // Usage (0x01)
+	0xa1, 0x01, // Collection (Application)
+	0x85, 0x02, //   Report ID (2)
+	0x75, 0x08, //   Report Size (8)
+	0x95, 0x3f, //   Report Count (63)
+	0x91, 0x02, //   Output (Data,Var,Abs)
+	0x85, 0x03, //   Report ID (3)
+	0x95, 0x10, //   Report Count (16)
+	0x81, 0x02, //   Input (Data,Var,Abs)
+	0x85, 0x05, //   Report ID (5)
+	0x75, 0x01, //   Report Size (1)
+	0x95, 0x0c, //   Report Count (12)
+	0xb1, 0x02, //   Feature (Data,Var,Abs)
+	0xc0, //       End Collection
+}
+
+// readReport reads an input report from d, failing the test on timeout.
+func readReport(t *testing.T, d hid.DeviceIO) []byte {
+	t.Helper()
+	b := make([]byte, 65)
+	n, err := d.ReadWithTimeout(b, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b[:n]
+}
+
+func TestSimulator(t *testing.T) {
+	s, err := NewSimulator(hid.DeviceInfo{VendorID: 0x04d8, ProductID: 0x003f}, testDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Info.Collections) != 1 {
+		t.Errorf("got %d collections, want 1", len(s.Info.Collections))
+	}
+
+	state := byte(0x01)
+	s.OnGetFeature(0x05, func() []byte { return []byte{0x05, state} })
+	s.OnOutput(0x02, func(report []byte) {
+		state = report[1]
+		s.SendAfter(5*time.Millisecond, []byte{0x03, report[1]})
+	})
+
+	b := []byte{0x05, 0x00, 0x00, 0x00}
+	n, err := s.GetFeatureReport(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x05, 0x01, 0x00}; !bytes.Equal(b[:n], want) {
+		t.Errorf("got %x, want %x", b[:n], want)
+	}
+
+	if _, err := s.Write([]byte{0x02, 0x7f}); err != nil {
+		t.Fatal(err)
+	}
+	report := readReport(t, s)
+	if len(report) != 17 || report[0] != 0x03 || report[1] != 0x7f {
+		t.Errorf("got %x, want 037f padded to 17 bytes", report)
+	}
+}
+
+func TestSimulatorEvery(t *testing.T) {
+	s, err := NewSimulator(hid.DeviceInfo{}, testDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n byte
+	s.Every(time.Millisecond, func() []byte {
+		n++
+		return []byte{0x03, n}
+	})
+	s.Start()
+	first, second := readReport(t, s), readReport(t, s)
+	s.Stop()
+	if first[1] != 1 || second[1] != 2 {
+		t.Errorf("got %x, %x; want sequential reports", first, second)
+	}
+}
+
+func TestScript(t *testing.T) {
+	sc, err := LoadScript("testdata/sim.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := sc.Simulator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Info.VendorID != 0x04d8 || s.Info.ProductStr != "Simulated Device" {
+		t.Errorf("unexpected device information: %+v", s.Info)
+	}
+	if str, _ := s.GetIndexedStr(1); str != "Indexed String 1" {
+		t.Errorf("got %q, want %q", str, "Indexed String 1")
+	}
+
+	b := []byte{0x05, 0x00, 0x00}
+	if _, err := s.GetFeatureReport(b); err != nil {
+		t.Fatal(err)
+	}
+	if b[1] != 0x01 {
+		t.Errorf("got %x, want 0501", b)
+	}
+
+	s.Write([]byte{0x02, 0x80})
+	if report := readReport(t, s); report[1] != 0x80 {
+		t.Errorf("got %x, want 0380", report[:2])
+	}
+	s.Write([]byte{0x02, 0x00})
+	for _, want := range []byte{0x01, 0x02} {
+		if report := readReport(t, s); report[1] != want {
+			t.Errorf("got %x, want 03%02x", report[:2], want)
+		}
+	}
+
+	s.Start()
+	defer s.Stop()
+	if report := readReport(t, s); report[1] != 0xaa {
+		t.Errorf("got %x, want 03aa", report[:2])
+	}
+}
+
+func TestParseScriptInvalid(t *testing.T) {
+	for _, s := range []string{
+		`{"descriptor": "zz"}`,
+		`{"unknown": true}`,
+		`{"periodic": [{"interval": "1x"}]}`,
+	} {
+		if _, err := ParseScript(bytes.NewBufferString(s)); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+}
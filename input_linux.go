@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"fmt"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InputCapabilities is a capability bitmap of an input device as reported by
+// the kernel. Bit n is set if the device supports event code n; for example,
+// bit KEY_A (30) of the key bitmap is set for a keyboard.
+type InputCapabilities []uint64
+
+// Has reports whether bit n is set in the bitmap.
+func (c InputCapabilities) Has(n int) bool {
+	if n < 0 || n/64 >= len(c) {
+		return false
+	}
+	return c[n/64]&(1<<(uint(n)%64)) != 0
+}
+
+// parseInputCapabilities parses a capability bitmap as formatted by the
+// kernel: words of the native long size in hexadecimal, most significant word
+// first, separated by spaces.
+func parseInputCapabilities(s string) (InputCapabilities, error) {
+	words := strings.Fields(s)
+	var c InputCapabilities
+	for i := range words {
+		word, err := strconv.ParseUint(words[len(words)-1-i], 16, bits.UintSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid capability bitmap %q: %w", s, err)
+		}
+		for ; word != 0; word &= word - 1 {
+			n := i*bits.UintSize + bits.TrailingZeros64(word)
+			for len(c) <= n/64 {
+				c = append(c, 0)
+			}
+			c[n/64] |= 1 << (uint(n) % 64)
+		}
+	}
+	return c, nil
+}
+
+// LinuxInputDevice describes an input device registered by the Linux kernel
+// for a HID device. Input devices share their parent HID device with the
+// hidraw device node used by this package.
+type LinuxInputDevice struct {
+	Name         string                       // Device Name
+	Phys         string                       // Physical Location
+	Uniq         string                       // Unique Identifier
+	Syspath      string                       // sysfs Path of Input Device
+	Nodes        []string                     // Device Nodes (event, js, and mouse)
+	Capabilities map[string]InputCapabilities // Capability Bitmaps by Type (ev, key, rel, ...)
+}
+
+// isInputNodeName reports whether name is the name of an input device node
+// handled by the evdev, joydev, or mousedev drivers.
+func isInputNodeName(name string) bool {
+	for _, prefix := range []string{"event", "js", "mouse"} {
+		if n := strings.TrimPrefix(name, prefix); n != name {
+			_, err := strconv.Atoi(n)
+			return err == nil
+		}
+	}
+	return false
+}
+
+// sortByNumber sorts names by prefix and numeric suffix, such that input2
+// sorts before input10.
+func sortByNumber(names []string) {
+	split := func(name string) (string, int) {
+		name = filepath.Base(name)
+		prefix := strings.TrimRight(name, "0123456789")
+		n, _ := strconv.Atoi(name[len(prefix):])
+		return prefix, n
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		pi, ni := split(names[i])
+		pj, nj := split(names[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return ni < nj
+	})
+}
+
+// LinuxInputDevices returns the input devices registered for the HID device
+// described by info and an error, if any. Device nodes are returned for the
+// evdev (/dev/input/eventN), joydev (/dev/input/jsN), and mousedev
+// (/dev/input/mouseN) drivers.
+func LinuxInputDevices(info *DeviceInfo) ([]*LinuxInputDevice, error) {
+	dir, err := hidSysfsDir(info.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", info.Path, err)
+	}
+
+	inputs, _ := filepath.Glob(filepath.Join(dir, "input", "input*"))
+	sortByNumber(inputs)
+
+	var devs []*LinuxInputDevice
+	for _, input := range inputs {
+		dev := &LinuxInputDevice{
+			Name:         sysfsAttr(input, "name"),
+			Phys:         sysfsAttr(input, "phys"),
+			Uniq:         sysfsAttr(input, "uniq"),
+			Syspath:      input,
+			Capabilities: make(map[string]InputCapabilities),
+		}
+
+		entries, err := os.ReadDir(input)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, entry := range entries {
+			if isInputNodeName(entry.Name()) {
+				names = append(names, entry.Name())
+			}
+		}
+		sortByNumber(names)
+		for _, name := range names {
+			devname := sysfsUevent(filepath.Join(input, name))["DEVNAME"]
+			if devname == "" {
+				devname = filepath.Join("input", name)
+			}
+			dev.Nodes = append(dev.Nodes, filepath.Join("/dev", devname))
+		}
+
+		caps, _ := filepath.Glob(filepath.Join(input, "capabilities", "*"))
+		for _, path := range caps {
+			c, err := parseInputCapabilities(sysfsAttr(filepath.Dir(path), filepath.Base(path)))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			dev.Capabilities[filepath.Base(path)] = c
+		}
+		devs = append(devs, dev)
+	}
+	return devs, nil
+}
+
+// LinuxInputDevices returns the input devices registered for the Device and
+// an error, if any.
+func (d *Device) LinuxInputDevices() ([]*LinuxInputDevice, error) {
+	info, err := d.GetDeviceInfo()
+	if err != nil {
+		return nil, err
+	}
+	return LinuxInputDevices(info)
+}
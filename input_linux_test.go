@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"math/bits"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLinuxInputDevices(t *testing.T) {
+	fs := newFakeSysfs(t)
+	devs, err := LinuxInputDevices(&DeviceInfo{Path: "/dev/hidraw3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devs) != 1 {
+		t.Fatalf("got %d input devices, want 1", len(devs))
+	}
+
+	dev := devs[0]
+	if want := filepath.Join(fs.hidDev, "input", "input7"); dev.Syspath != want {
+		t.Errorf("got syspath %s, want %s", dev.Syspath, want)
+	}
+	if want := "Logitech USB Receiver"; dev.Name != want {
+		t.Errorf("got name %q, want %q", dev.Name, want)
+	}
+	if want := []string{"/dev/input/event5"}; !reflect.DeepEqual(dev.Nodes, want) {
+		t.Errorf("got nodes %v, want %v", dev.Nodes, want)
+	}
+
+	const (
+		evKey = 0x01
+		evRel = 0x02
+		evLED = 0x11
+		keyA  = 30
+	)
+	ev := dev.Capabilities["ev"]
+	if !ev.Has(evKey) || !ev.Has(evLED) || ev.Has(evRel) {
+		t.Errorf("unexpected ev capabilities: %x", ev)
+	}
+	if key := dev.Capabilities["key"]; !key.Has(keyA) || key.Has(0) || !key.Has(240) {
+		t.Errorf("unexpected key capabilities: %x", key)
+	}
+}
+
+func TestParseInputCapabilities(t *testing.T) {
+	tests := []struct {
+		s    string
+		want InputCapabilities
+	}{
+		{"0", nil},
+		{"120013", InputCapabilities{0x120013}},
+	}
+	for _, tt := range tests {
+		got, err := parseInputCapabilities(tt.s)
+		if err != nil {
+			t.Errorf("%q: %v", tt.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %x, want %x", tt.s, got, tt.want)
+		}
+	}
+
+	// Words are the size of a native long, least significant word last.
+	c, err := parseInputCapabilities("3 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Has(0) || !c.Has(bits.UintSize) || !c.Has(bits.UintSize+1) {
+		t.Errorf("got %x, want bits %d and %d set", c, bits.UintSize, bits.UintSize+1)
+	}
+
+	if _, err := parseInputCapabilities("xyz"); err == nil {
+		t.Error("expected error for invalid bitmap")
+	}
+}
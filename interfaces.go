@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"io"
+	"time"
+)
+
+// DeviceIO is the interface implemented by HID devices. It is implemented by
+// Device; programs that accept a DeviceIO rather than a *Device may be tested
+// without hardware using the fake devices provided by package hidtest.
+type DeviceIO interface {
+	io.ReadWriteCloser
+
+	ReadWithTimeout(p []byte, timeout time.Duration) (int, error)
+	SetNonblock(nonblocking bool) error
+	SendFeatureReport(p []byte) (int, error)
+	GetFeatureReport(p []byte) (int, error)
+	GetInputReport(p []byte) (int, error)
+	SendOutputReport(p []byte) (int, error)
+	GetMfrStr() (string, error)
+	GetProductStr() (string, error)
+	GetSerialNbr() (string, error)
+	GetIndexedStr(index int) (string, error)
+	GetDeviceInfo() (*DeviceInfo, error)
+	GetReportDescriptor(p []byte) (int, error)
+}
+
+var _ DeviceIO = (*Device)(nil)
+
+// Enumerator is the interface implemented by sources of HID devices. The
+// HID devices attached to the system are provided by System.
+type Enumerator interface {
+	// Enumerate visits each HID device with a matching vendor and product
+	// ID. See the Enumerate function for details.
+	Enumerate(vid, pid uint16, enumFn EnumFunc) error
+
+	// Open opens the HID device with a matching vendor ID, product ID,
+	// and serial number.
+	Open(vid, pid uint16, serial string) (DeviceIO, error)
+
+	// OpenFirst opens the first HID device with a matching vendor ID and
+	// product ID.
+	OpenFirst(vid, pid uint16) (DeviceIO, error)
+
+	// OpenPath opens the HID device with the given path.
+	OpenPath(path string) (DeviceIO, error)
+}
+
+// System is an Enumerator for the HID devices attached to the system. Its
+// methods call the package-level functions of the same name.
+var System Enumerator = system{}
+
+type system struct{}
+
+func (system) Enumerate(vid, pid uint16, enumFn EnumFunc) error {
+	return Enumerate(vid, pid, enumFn)
+}
+
+func (system) Open(vid, pid uint16, serial string) (DeviceIO, error) {
+	return wrapDevice(Open(vid, pid, serial))
+}
+
+func (system) OpenFirst(vid, pid uint16) (DeviceIO, error) {
+	return wrapDevice(OpenFirst(vid, pid))
+}
+
+func (system) OpenPath(path string) (DeviceIO, error) {
+	return wrapDevice(OpenPath(path))
+}
+
+// wrapDevice returns d as a DeviceIO. A nil *Device is returned as a nil
+// interface value rather than a non-nil DeviceIO holding a nil pointer.
+func wrapDevice(d *Device, err error) (DeviceIO, error) {
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:build cgo && libusb && !purego && !nolibudev && !systemhidapi
+
+package hid
+
+/*
+#include "hidapi.h"
+
+// The HIDAPI hidraw backend is linked alongside the libusb backend; its
+// symbols are prefixed by go_hidraw_ (see hidapi_namespace.h).
+struct hid_device_info *go_hidraw_hid_enumerate(unsigned short vendor_id, unsigned short product_id);
+void go_hidraw_hid_free_enumeration(struct hid_device_info *devs);
+int go_hidraw_hid_exit(void);
+*/
+import "C"
+
+// libusbHidrawEnumerate enumerates devices for the hidraw backend using the
+// HIDAPI hidraw backend. As in programs built without the libusb build
+// constraint, devices are enumerated using sysfs if libudev finds no devices.
+func libusbHidrawEnumerate(vid, pid uint16) []*DeviceInfo {
+	setError(nil)
+	p := C.go_hidraw_hid_enumerate(C.ushort(vid), C.ushort(pid))
+	defer C.go_hidraw_hid_exit() // release the global error, if any
+	defer C.go_hidraw_hid_free_enumeration(p)
+
+	v := sysVersion()
+	var devs []*DeviceInfo
+	for ; p != nil; p = p.next {
+		devs = append(devs, newDeviceInfo(v, p))
+	}
+	if len(devs) == 0 {
+		return hidrawEnumerate(vid, pid)
+	}
+	return devs
+}
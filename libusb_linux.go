@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:build cgo && libusb && !purego
+
+package hid
+
+// Both the libusb and hidraw backends are available; libusb is the default to
+// preserve the behavior of the libusb build constraint.
+var sysBackends = []Backend{BackendLibusb, BackendHidraw}
+
+func sysEnumerate(b Backend, vid, pid uint16) []*DeviceInfo {
+	if b == BackendHidraw {
+		return libusbHidrawEnumerate(vid, pid)
+	}
+	return hidapiEnumerate(vid, pid)
+}
+
+func sysOpen(b Backend, vid, pid uint16, serial *string) (*Device, error) {
+	if b == BackendHidraw {
+		return hidrawOpen(vid, pid, serial)
+	}
+	return hidapiOpen(vid, pid, serial)
+}
+
+func sysOpenPath(b Backend, path string) (*Device, error) {
+	if b == BackendHidraw {
+		return hidrawOpenPath(path)
+	}
+	return hidapiOpenPath(path)
+}
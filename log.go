@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:build go1.21
+
+package hid
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// redacted replaces serial numbers in log records if LogOptions.RedactSerial
+// is set.
+const redacted = "REDACTED"
+
+// LogOptions configures the records logged by a Tracer returned by
+// NewLogTracer.
+type LogOptions struct {
+	// Payloads, if true, logs reports and descriptors transferred to and
+	// from devices at debug level.
+	Payloads bool
+
+	// RedactSerial, if true, replaces serial numbers with "REDACTED",
+	// including serial numbers appearing in device paths.
+	RedactSerial bool
+}
+
+// logTracer is a Tracer which logs operations using a slog.Logger.
+type logTracer struct {
+	logger *slog.Logger
+	opts   LogOptions
+}
+
+// NewLogTracer returns a Tracer which logs operations to logger. Opens and
+// closes are logged at info level and errors at error level; timeouts are not
+// logged. If opts is nil, the zero value is used.
+func NewLogTracer(logger *slog.Logger, opts *LogOptions) Tracer {
+	t := &logTracer{logger: logger}
+	if opts != nil {
+		t.opts = *opts
+	}
+	return t
+}
+
+// SetLogger sets the logger used for devices opened by Open, OpenFirst, and
+// OpenPath; it is equivalent to calling SetTracer with a Tracer returned by
+// NewLogTracer. If logger is nil, logging is disabled.
+func SetLogger(logger *slog.Logger, opts *LogOptions) {
+	if logger == nil {
+		SetTracer(nil)
+		return
+	}
+	SetTracer(NewLogTracer(logger, opts))
+}
+
+// SetLogger sets the logger used for operations on d; it is equivalent to
+// calling d.SetTracer with a Tracer returned by NewLogTracer. If logger is
+// nil, logging is disabled.
+func (d *Device) SetLogger(logger *slog.Logger, opts *LogOptions) {
+	if logger == nil {
+		d.SetTracer(nil)
+		return
+	}
+	d.SetTracer(NewLogTracer(logger, opts))
+}
+
+func (t *logTracer) Trace(e *TraceEvent) {
+	ctx := context.Background()
+	level := slog.LevelDebug
+	switch {
+	case errors.Is(e.Err, ErrTimeout):
+		return
+	case e.Err != nil:
+		level = slog.LevelError
+	case e.Op == OpOpen || e.Op == OpClose:
+		level = slog.LevelInfo
+	case !t.opts.Payloads || e.Data == nil:
+		return
+	}
+	if !t.logger.Enabled(ctx, level) {
+		return
+	}
+
+	serial := e.SerialNbr
+	if serial == "" && (e.Op == OpOpen || e.Op == OpGetSerialNbr) {
+		serial = e.Str
+	}
+	path := e.Path
+	var errStr string
+	if e.Err != nil {
+		errStr = e.Err.Error()
+	}
+	if t.opts.RedactSerial && serial != "" {
+		path = strings.ReplaceAll(path, serial, redacted)
+		errStr = strings.ReplaceAll(errStr, serial, redacted)
+		serial = redacted
+	}
+
+	attrs := []slog.Attr{slog.String("op", e.Op.String())}
+	if path != "" {
+		attrs = append(attrs, slog.String("path", path))
+	}
+	if e.VendorID != 0 || e.ProductID != 0 {
+		attrs = append(attrs, slog.String("vid", fmt.Sprintf("%04x", e.VendorID)),
+			slog.String("pid", fmt.Sprintf("%04x", e.ProductID)))
+	}
+	if serial != "" {
+		attrs = append(attrs, slog.String("serial", serial))
+	}
+	if t.opts.Payloads && e.Data != nil {
+		attrs = append(attrs, slog.Int("len", len(e.Data)))
+		switch e.Op {
+		case OpWrite, OpSendFeatureReport, OpGetFeatureReport, OpGetInputReport, OpSendOutputReport:
+			// Reports begin with the report ID, or 0 if the device
+			// does not use numbered reports. Input reports returned
+			// by Read only begin with the report ID if numbered.
+			attrs = append(attrs, slog.Int("report_id", int(e.Data[0])))
+		}
+		attrs = append(attrs, slog.String("data", hex.EncodeToString(e.Data)))
+	}
+	attrs = append(attrs, slog.Duration("duration", e.Duration))
+	if e.Err != nil {
+		attrs = append(attrs, slog.String("error", errStr))
+	}
+	t.logger.LogAttrs(ctx, level, "hid: "+strings.ReplaceAll(e.Op.String(), "_", " "), attrs...)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+//go:build go1.21
+
+package hid
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogTracer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	tracer := NewLogTracer(logger, &LogOptions{Payloads: true, RedactSerial: true})
+
+	tracer.Trace(&TraceEvent{Op: OpOpen, Path: `\\?\hid#vid_1234&pid_5678#SN123#{guid}`, Str: "SN123"})
+	tracer.Trace(&TraceEvent{Op: OpWrite, Path: "/dev/hidraw0", Data: []byte{0x02, 0xab}, N: 2})
+	tracer.Trace(&TraceEvent{Op: OpRead, Path: "/dev/hidraw0", Err: ErrTimeout})
+	tracer.Trace(&TraceEvent{Op: OpRead, Path: "/dev/hidraw0", Data: []byte{0x01, 0x02}, N: 2})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d records, want 3:\n%s", len(lines), buf.String())
+	}
+	if strings.Contains(lines[0], "SN123") || !strings.Contains(lines[0], "serial=REDACTED") {
+		t.Errorf("serial number not redacted: %s", lines[0])
+	}
+	for _, s := range []string{"level=DEBUG", "op=write", "len=2", "report_id=2", "data=02ab"} {
+		if !strings.Contains(lines[1], s) {
+			t.Errorf("got %s, want %s", lines[1], s)
+		}
+	}
+	if strings.Contains(lines[2], "report_id") {
+		t.Errorf("report ID logged for input report: %s", lines[2])
+	}
+
+	buf.Reset()
+	path := "1-2:1.0/SN123"
+	tracer.Trace(&TraceEvent{Op: OpRead, Path: path, SerialNbr: "SN123", Data: []byte{0x01}, N: 1})
+	tracer.Trace(&TraceEvent{Op: OpClose, Path: path, SerialNbr: "SN123"})
+	if strings.Contains(buf.String(), "SN123") {
+		t.Errorf("serial number not redacted:\n%s", buf.String())
+	}
+	if n := strings.Count(buf.String(), "path=1-2:1.0/REDACTED"); n != 2 {
+		t.Errorf("got %d redacted paths, want 2:\n%s", n, buf.String())
+	}
+
+	buf.Reset()
+	NewLogTracer(logger, nil).Trace(&TraceEvent{Op: OpWrite, Data: []byte{0x02}, N: 1})
+	if buf.Len() != 0 {
+		t.Errorf("payload logged without LogOptions.Payloads: %s", buf.String())
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseBusType returns the BusType named by s and an error, if any. Names are
+// matched without regard to case and are those returned by String, for
+// example "USB" or "Bluetooth".
+func ParseBusType(s string) (BusType, error) {
+	for t := BusUnknown; t <= BusSPI; t++ {
+		if strings.EqualFold(s, t.String()) {
+			return t, nil
+		}
+	}
+
+	// Accept the form returned by String for undefined values so that
+	// values round-trip.
+	if v := strings.TrimSuffix(strings.TrimPrefix(s, "BusType("), ")"); len(v) != len(s) {
+		if n, err := strconv.Atoi(v); err == nil {
+			return BusType(n), nil
+		}
+	}
+	return BusUnknown, fmt.Errorf("invalid bus type: %q", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t BusType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *BusType) UnmarshalText(text []byte) error {
+	v, err := ParseBusType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+var (
+	_ encoding.TextMarshaler   = BusType(0)
+	_ encoding.TextUnmarshaler = (*BusType)(nil)
+)
+
+// hexUint16 is a uint16 that is encoded as a JSON string containing a
+// 0x-prefixed, zero-padded, lowercase hexadecimal number. Strings containing
+// a decimal number and JSON numbers are also accepted when decoding.
+type hexUint16 uint16
+
+func (n hexUint16) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%#04x"`, uint16(n))), nil
+}
+
+func (n *hexUint16) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	base := 10
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+		if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+			s, base = s[2:], 16
+		}
+	}
+	v, err := strconv.ParseUint(s, base, 16)
+	if err != nil {
+		return fmt.Errorf("invalid 16-bit value: %s", b)
+	}
+	*n = hexUint16(v)
+	return nil
+}
+
+type collectionJSON struct {
+	UsagePage hexUint16 `json:"usage_page"`
+	Usage     hexUint16 `json:"usage"`
+	ReportIDs []int     `json:"report_ids,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. See
+// DeviceInfo.MarshalJSON for the schema.
+func (c Collection) MarshalJSON() ([]byte, error) {
+	v := collectionJSON{
+		UsagePage: hexUint16(c.UsagePage),
+		Usage:     hexUint16(c.Usage),
+	}
+	for _, id := range c.ReportIDs {
+		v.ReportIDs = append(v.ReportIDs, int(id))
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *Collection) UnmarshalJSON(b []byte) error {
+	var v collectionJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*c = Collection{
+		UsagePage: uint16(v.UsagePage),
+		Usage:     uint16(v.Usage),
+	}
+	for _, id := range v.ReportIDs {
+		if id < 1 || id > 0xff {
+			return fmt.Errorf("invalid report ID: %d", id)
+		}
+		c.ReportIDs = append(c.ReportIDs, byte(id))
+	}
+	return nil
+}
+
+type deviceInfoJSON struct {
+	Path         string       `json:"path"`
+	VendorID     hexUint16    `json:"vendor_id"`
+	ProductID    hexUint16    `json:"product_id"`
+	SerialNbr    string       `json:"serial_number"`
+	ReleaseNbr   hexUint16    `json:"release_number"`
+	MfrStr       string       `json:"manufacturer_string"`
+	ProductStr   string       `json:"product_string"`
+	UsagePage    hexUint16    `json:"usage_page"`
+	Usage        hexUint16    `json:"usage"`
+	InterfaceNbr int          `json:"interface_number"`
+	BusType      BusType      `json:"bus_type"`
+	PortPath     string       `json:"port_path,omitempty"`
+	Collections  []Collection `json:"collections,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. Device information is
+// encoded as a JSON object using the field names of the hid_device_info
+// structure defined by HIDAPI:
+//
+//	{
+//		"path": "/dev/hidraw3",
+//		"vendor_id": "0x046d",
+//		"product_id": "0xc52b",
+//		"serial_number": "",
+//		"release_number": "0x1211",
+//		"manufacturer_string": "Logitech",
+//		"product_string": "USB Receiver",
+//		"usage_page": "0x0001",
+//		"usage": "0x0006",
+//		"interface_number": 0,
+//		"bus_type": "USB",
+//		"port_path": "1-2.4",
+//		"collections": [
+//			{"usage_page": "0x0001", "usage": "0x0006", "report_ids": [1, 2]}
+//		]
+//	}
+//
+// IDs, release numbers, and usages are encoded as strings containing
+// 0x-prefixed, zero-padded, lowercase hexadecimal numbers. The bus type is
+// encoded as returned by BusType.String. The port_path and collections members
+// are omitted if unavailable. The path is platform-specific and should be
+// treated as opaque.
+func (info DeviceInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(deviceInfoJSON{
+		Path:         info.Path,
+		VendorID:     hexUint16(info.VendorID),
+		ProductID:    hexUint16(info.ProductID),
+		SerialNbr:    info.SerialNbr,
+		ReleaseNbr:   hexUint16(info.ReleaseNbr),
+		MfrStr:       info.MfrStr,
+		ProductStr:   info.ProductStr,
+		UsagePage:    hexUint16(info.UsagePage),
+		Usage:        hexUint16(info.Usage),
+		InterfaceNbr: info.InterfaceNbr,
+		BusType:      info.BusType,
+		PortPath:     info.PortPath,
+		Collections:  info.Collections,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. IDs, release
+// numbers, and usages may be encoded as JSON numbers or strings containing
+// numbers in any base accepted by strconv.ParseUint.
+func (info *DeviceInfo) UnmarshalJSON(b []byte) error {
+	v := deviceInfoJSON{InterfaceNbr: -1}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*info = DeviceInfo{
+		Path:         v.Path,
+		VendorID:     uint16(v.VendorID),
+		ProductID:    uint16(v.ProductID),
+		SerialNbr:    v.SerialNbr,
+		ReleaseNbr:   uint16(v.ReleaseNbr),
+		MfrStr:       v.MfrStr,
+		ProductStr:   v.ProductStr,
+		UsagePage:    uint16(v.UsagePage),
+		Usage:        uint16(v.Usage),
+		InterfaceNbr: v.InterfaceNbr,
+		BusType:      v.BusType,
+		PortPath:     v.PortPath,
+		Collections:  v.Collections,
+	}
+	return nil
+}
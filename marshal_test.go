@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseBusType(t *testing.T) {
+	for _, want := range []BusType{BusUnknown, BusUSB, BusBluetooth, BusI2C, BusSPI, BusType(42)} {
+		text, err := want.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got BusType
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%s: got %v, want %v", text, got, want)
+		}
+	}
+	if got, err := ParseBusType("bluetooth"); err != nil || got != BusBluetooth {
+		t.Errorf("got %v, %v; want %v", got, err, BusBluetooth)
+	}
+	if _, err := ParseBusType("FireWire"); err == nil {
+		t.Error("expected error for invalid bus type")
+	}
+}
+
+var testDeviceInfo = DeviceInfo{
+	Path:         "/dev/hidraw3",
+	VendorID:     0x046d,
+	ProductID:    0xc52b,
+	ReleaseNbr:   0x1211,
+	MfrStr:       "Logitech",
+	ProductStr:   "USB Receiver",
+	UsagePage:    0x0001,
+	Usage:        0x0006,
+	InterfaceNbr: 0,
+	BusType:      BusUSB,
+	PortPath:     "1-2.4",
+	Collections: []Collection{
+		{UsagePage: 0x0001, Usage: 0x0006},
+		{UsagePage: 0xff00, Usage: 0x0001, ReportIDs: []byte{0x10, 0x11}},
+	},
+}
+
+const testDeviceInfoJSON = `{"path":"/dev/hidraw3","vendor_id":"0x046d","product_id":"0xc52b",` +
+	`"serial_number":"","release_number":"0x1211","manufacturer_string":"Logitech",` +
+	`"product_string":"USB Receiver","usage_page":"0x0001","usage":"0x0006",` +
+	`"interface_number":0,"bus_type":"USB","port_path":"1-2.4","collections":[` +
+	`{"usage_page":"0x0001","usage":"0x0006"},` +
+	`{"usage_page":"0xff00","usage":"0x0001","report_ids":[16,17]}]}`
+
+func TestDeviceInfoMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(&testDeviceInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != testDeviceInfoJSON {
+		t.Errorf("got %s, want %s", b, testDeviceInfoJSON)
+	}
+}
+
+func TestDeviceInfoUnmarshalJSON(t *testing.T) {
+	var got DeviceInfo
+	if err := json.Unmarshal([]byte(testDeviceInfoJSON), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, testDeviceInfo) {
+		t.Errorf("got %+v, want %+v", got, testDeviceInfo)
+	}
+
+	// Numbers are accepted in place of hexadecimal strings.
+	got = DeviceInfo{}
+	if err := json.Unmarshal([]byte(`{"vendor_id":1133,"product_id":"50475"}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.VendorID != 0x046d || got.ProductID != 0xc52b || got.InterfaceNbr != -1 {
+		t.Errorf("got %+v", got)
+	}
+
+	// Strings with a leading zero are decimal, not octal.
+	got = DeviceInfo{}
+	if err := json.Unmarshal([]byte(`{"vendor_id":"0755","product_id":"0X00ff"}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.VendorID != 755 || got.ProductID != 0xff {
+		t.Errorf("got %+v", got)
+	}
+
+	for _, s := range []string{
+		`{"vendor_id":"0x10000"}`,
+		`{"vendor_id":"0b1"}`,
+		`{"vendor_id":"0x"}`,
+		`{"bus_type":"FireWire"}`,
+		`{"collections":[{"report_ids":[256]}]}`,
+	} {
+		if err := json.Unmarshal([]byte(s), &got); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package metrics
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/sstallion/go-hid"
+)
+
+// ErrorKind classifies errors returned by operations on a device.
+type ErrorKind int
+
+const (
+	ErrorOther        ErrorKind = iota // Other Errors
+	ErrorTimeout                       // Operation Timed Out
+	ErrorDisconnected                  // Device Disconnected (ENODEV, ENXIO)
+	ErrorIO                            // I/O Error or Stalled Endpoint (EIO, EPIPE)
+	ErrorPermission                    // Permission Denied (EACCES, EPERM)
+)
+
+// numKinds is the number of error kinds.
+const numKinds = int(ErrorPermission) + 1
+
+var kindNames = [numKinds]string{
+	ErrorOther:        "other",
+	ErrorTimeout:      "timeout",
+	ErrorDisconnected: "disconnected",
+	ErrorIO:           "io",
+	ErrorPermission:   "permission",
+}
+
+func (k ErrorKind) String() string {
+	if k < 0 || int(k) >= numKinds {
+		return "unknown"
+	}
+	return kindNames[k]
+}
+
+// kindMessages are substrings of the error messages reported by HIDAPI, which
+// does not preserve error numbers, for each kind of error.
+var kindMessages = []struct {
+	kind ErrorKind
+	msgs []string
+}{
+	{ErrorDisconnected, []string{"no such device", "libusb_error_no_device", "not connected", "disconnected"}},
+	{ErrorIO, []string{"input/output error", "libusb_error_io", "broken pipe", "libusb_error_pipe"}},
+	{ErrorPermission, []string{"permission denied", "access denied", "libusb_error_access"}},
+}
+
+// Classify returns the kind of err. Errors wrapping error numbers are
+// classified by number; other errors, such as those reported by HIDAPI, are
+// classified by message.
+func Classify(err error) ErrorKind {
+	switch {
+	case errors.Is(err, hid.ErrTimeout):
+		return ErrorTimeout
+	case errors.Is(err, syscall.ENODEV), errors.Is(err, syscall.ENXIO):
+		return ErrorDisconnected
+	case errors.Is(err, syscall.EIO), errors.Is(err, syscall.EPIPE):
+		return ErrorIO
+	case errors.Is(err, os.ErrPermission):
+		return ErrorPermission
+	}
+	msg := strings.ToLower(err.Error())
+	for _, km := range kindMessages {
+		for _, s := range km.msgs {
+			if strings.Contains(msg, s) {
+				return km.kind
+			}
+		}
+	}
+	return ErrorOther
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package metrics
+
+import (
+	"errors"
+	"expvar"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// bounds are the upper bounds of histogram buckets.
+var bounds = [numBuckets - 1]time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	1 * time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// numBuckets is the number of histogram buckets, including the overflow
+// bucket.
+const numBuckets = 17
+
+// histogram is a histogram of durations updated using atomic operations.
+type histogram struct {
+	counts [numBuckets]uint64
+	sum    uint64 // nanoseconds
+}
+
+func (h *histogram) observe(d time.Duration) {
+	i := sort.Search(len(bounds), func(i int) bool { return d <= bounds[i] })
+	atomic.AddUint64(&h.counts[i], 1)
+	atomic.AddUint64(&h.sum, uint64(d))
+}
+
+func (h *histogram) snapshot() Histogram {
+	s := Histogram{Bounds: append([]time.Duration(nil), bounds[:]...), Counts: make([]uint64, numBuckets)}
+	for i := range h.counts {
+		s.Counts[i] = atomic.LoadUint64(&h.counts[i])
+		s.Count += s.Counts[i]
+	}
+	s.Sum = time.Duration(atomic.LoadUint64(&h.sum))
+	return s
+}
+
+// Histogram is a snapshot of a histogram of durations.
+type Histogram struct {
+	Bounds []time.Duration // Upper Bounds of Buckets
+	Counts []uint64        // Observations per Bucket (last is Overflow)
+	Count  uint64          // Total Observations
+	Sum    time.Duration   // Sum of Observations
+}
+
+// counters holds the metrics of a device. All fields are updated using
+// atomic operations; 64-bit fields must remain first for alignment.
+type counters struct {
+	reportsRead    uint64
+	reportsWritten uint64
+	bytesRead      uint64
+	bytesWritten   uint64
+	timeouts       uint64
+	opens          uint64
+	lastRead       int64 // Unix nanoseconds
+	errors         [numOps][numKinds]uint64
+	readInterval   histogram
+	writeLatency   histogram
+	open           int32
+}
+
+// numOps is one greater than the largest hid.Op.
+const numOps = int(hid.OpGetReportDescriptor) + 1
+
+// Device is a snapshot of the metrics of a device.
+type Device struct {
+	Path           string                       // Device Path
+	Open           bool                         // Device Open
+	Opens          uint64                       // Number of Times Opened
+	ReportsRead    uint64                       // Reports Read (Including Get Report Requests)
+	ReportsWritten uint64                       // Reports Written (Including Set Report Requests)
+	BytesRead      uint64                       // Bytes Read
+	BytesWritten   uint64                       // Bytes Written
+	Timeouts       uint64                       // Reads Timed Out
+	Errors         map[string]map[string]uint64 // Errors by Operation and Kind
+	ReadInterval   Histogram                    // Time Between Successive Reads
+	WriteLatency   Histogram                    // Duration of Writes
+}
+
+// Collector is a hid.Tracer which collects metrics for each device.
+type Collector struct {
+	mu      sync.RWMutex
+	devices map[string]*counters
+}
+
+// NewCollector returns a new Collector.
+func NewCollector() *Collector {
+	return &Collector{devices: make(map[string]*counters)}
+}
+
+// counters returns the counters for the device with the given path.
+func (c *Collector) counters(path string) *counters {
+	c.mu.RLock()
+	d := c.devices[path]
+	c.mu.RUnlock()
+	if d != nil {
+		return d
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d = c.devices[path]; d == nil {
+		d = new(counters)
+		c.devices[path] = d
+	}
+	return d
+}
+
+// Trace updates the metrics of the device described by e.
+func (c *Collector) Trace(e *hid.TraceEvent) {
+	if e.Op == hid.OpOpen && e.Err != nil {
+		return // the device was not opened
+	}
+	d := c.counters(e.Path)
+	switch {
+	case e.Op == hid.OpRead && errors.Is(e.Err, hid.ErrTimeout):
+		atomic.AddUint64(&d.timeouts, 1)
+		return
+	case e.Err != nil:
+		if e.Op > 0 && int(e.Op) < numOps {
+			atomic.AddUint64(&d.errors[e.Op][Classify(e.Err)], 1)
+		}
+		return
+	}
+
+	switch e.Op {
+	case hid.OpOpen:
+		atomic.AddUint64(&d.opens, 1)
+		atomic.StoreInt32(&d.open, 1)
+		atomic.StoreInt64(&d.lastRead, 0)
+	case hid.OpClose:
+		atomic.StoreInt32(&d.open, 0)
+	case hid.OpRead, hid.OpGetFeatureReport, hid.OpGetInputReport:
+		if e.N <= 0 {
+			return
+		}
+		atomic.AddUint64(&d.reportsRead, 1)
+		atomic.AddUint64(&d.bytesRead, uint64(e.N))
+		if e.Op == hid.OpRead {
+			now := time.Now().UnixNano()
+			if last := atomic.SwapInt64(&d.lastRead, now); last != 0 {
+				d.readInterval.observe(time.Duration(now - last))
+			}
+		}
+	case hid.OpWrite, hid.OpSendFeatureReport, hid.OpSendOutputReport:
+		atomic.AddUint64(&d.reportsWritten, 1)
+		if e.N > 0 {
+			atomic.AddUint64(&d.bytesWritten, uint64(e.N))
+		}
+		d.writeLatency.observe(e.Duration)
+	}
+}
+
+func (d *counters) snapshot(path string) Device {
+	s := Device{
+		Path:           path,
+		Open:           atomic.LoadInt32(&d.open) != 0,
+		Opens:          atomic.LoadUint64(&d.opens),
+		ReportsRead:    atomic.LoadUint64(&d.reportsRead),
+		ReportsWritten: atomic.LoadUint64(&d.reportsWritten),
+		BytesRead:      atomic.LoadUint64(&d.bytesRead),
+		BytesWritten:   atomic.LoadUint64(&d.bytesWritten),
+		Timeouts:       atomic.LoadUint64(&d.timeouts),
+		Errors:         make(map[string]map[string]uint64),
+		ReadInterval:   d.readInterval.snapshot(),
+		WriteLatency:   d.writeLatency.snapshot(),
+	}
+	for op := range d.errors {
+		for kind := range d.errors[op] {
+			n := atomic.LoadUint64(&d.errors[op][kind])
+			if n == 0 {
+				continue
+			}
+			name := hid.Op(op).String()
+			if s.Errors[name] == nil {
+				s.Errors[name] = make(map[string]uint64)
+			}
+			s.Errors[name][ErrorKind(kind).String()] = n
+		}
+	}
+	return s
+}
+
+// Snapshot returns the metrics of each device, sorted by path. Metrics are
+// retained after a device is closed; see Forget.
+func (c *Collector) Snapshot() []Device {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	devices := make([]Device, 0, len(c.devices))
+	for path, d := range c.devices {
+		devices = append(devices, d.snapshot(path))
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Path < devices[j].Path })
+	return devices
+}
+
+// Device returns the metrics of the device with the given path, and whether
+// metrics have been collected for the device.
+func (c *Collector) Device(path string) (Device, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	d, ok := c.devices[path]
+	if !ok {
+		return Device{}, false
+	}
+	return d.snapshot(path), true
+}
+
+// Forget discards the metrics of the device with the given path.
+func (c *Collector) Forget(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.devices, path)
+}
+
+// Var returns an expvar.Var which reports the metrics of each device as JSON.
+func (c *Collector) Var() expvar.Var {
+	return expvar.Func(func() interface{} { return c.Snapshot() })
+}
+
+var _ hid.Tracer = (*Collector)(nil)
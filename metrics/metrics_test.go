@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+const testPath = "/dev/hidraw0"
+
+func trace(c hid.Tracer, events ...hid.TraceEvent) {
+	for i := range events {
+		events[i].Path = testPath
+		c.Trace(&events[i])
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+	var opens int
+	tracer := hid.MultiTracer(c, hid.TracerFunc(func(e *hid.TraceEvent) {
+		if e.Op == hid.OpOpen {
+			opens++
+		}
+	}))
+	trace(tracer,
+		hid.TraceEvent{Op: hid.OpOpen},
+		hid.TraceEvent{Op: hid.OpWrite, N: 65, Duration: 2 * time.Millisecond},
+		hid.TraceEvent{Op: hid.OpRead, N: 64},
+		hid.TraceEvent{Op: hid.OpRead, N: 64},
+		hid.TraceEvent{Op: hid.OpRead, Err: hid.ErrTimeout},
+		hid.TraceEvent{Op: hid.OpRead, Err: fmt.Errorf("read: %w", hid.ErrTimeout)},
+		hid.TraceEvent{Op: hid.OpGetFeatureReport, N: 9},
+		hid.TraceEvent{Op: hid.OpWrite, N: -1, Err: errors.New("I/O error")},
+		hid.TraceEvent{Op: hid.OpClose},
+	)
+	if opens != 1 {
+		t.Errorf("got %d opens traced by MultiTracer, want 1", opens)
+	}
+
+	d, ok := c.Device(testPath)
+	if !ok {
+		t.Fatal("device not found")
+	}
+	want := Device{
+		Path:           testPath,
+		Opens:          1,
+		ReportsRead:    3,
+		ReportsWritten: 1,
+		BytesRead:      137,
+		BytesWritten:   65,
+		Timeouts:       2,
+	}
+	if d.Open || d.Opens != want.Opens || d.ReportsRead != want.ReportsRead ||
+		d.ReportsWritten != want.ReportsWritten || d.BytesRead != want.BytesRead ||
+		d.BytesWritten != want.BytesWritten || d.Timeouts != want.Timeouts {
+		t.Errorf("got %+v, want %+v", d, want)
+	}
+	if n := d.Errors["write"]["other"]; n != 1 {
+		t.Errorf("got %d write errors, want 1", n)
+	}
+	if d.ReadInterval.Count != 1 {
+		t.Errorf("got %d read intervals, want 1", d.ReadInterval.Count)
+	}
+	if h := d.WriteLatency; h.Count != 1 || h.Sum != 2*time.Millisecond || h.Counts[4] != 1 {
+		t.Errorf("got write latency %+v, want 1 observation of 2ms in bucket 4", h)
+	}
+
+	c.Forget(testPath)
+	if len(c.Snapshot()) != 0 {
+		t.Error("device not forgotten")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorKind
+	}{
+		{hid.ErrTimeout, ErrorTimeout},
+		{fmt.Errorf("read: %w", hid.ErrTimeout), ErrorTimeout},
+		{&os.PathError{Op: "read", Path: testPath, Err: syscall.ENODEV}, ErrorDisconnected},
+		{errors.New("hid_read_timeout: LIBUSB_ERROR_NO_DEVICE"), ErrorDisconnected},
+		{&os.PathError{Op: "read", Path: testPath, Err: syscall.EIO}, ErrorIO},
+		{syscall.EPIPE, ErrorIO},
+		{errors.New("hid_get_feature_report: LIBUSB_ERROR_PIPE"), ErrorIO},
+		{&os.PathError{Op: "open", Path: testPath, Err: syscall.EACCES}, ErrorPermission},
+		{errors.New("unspecified error"), ErrorOther},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.err); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	c := NewCollector()
+	trace(c,
+		hid.TraceEvent{Op: hid.OpOpen},
+		hid.TraceEvent{Op: hid.OpWrite, N: 65, Duration: 2 * time.Millisecond},
+		hid.TraceEvent{Op: hid.OpGetFeatureReport, Err: errors.New("broken pipe")},
+	)
+
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("got content type %q, want %q", ct, ContentType)
+	}
+	body := rec.Body.String()
+	for _, s := range []string{
+		"# TYPE hid_reports_written_total counter\n",
+		`hid_device_open{path="/dev/hidraw0"} 1` + "\n",
+		`hid_written_bytes_total{path="/dev/hidraw0"} 65` + "\n",
+		`hid_errors_total{path="/dev/hidraw0",op="get_feature_report",kind="io"} 1` + "\n",
+		`hid_write_latency_seconds_bucket{path="/dev/hidraw0",le="0.001"} 0` + "\n",
+		`hid_write_latency_seconds_bucket{path="/dev/hidraw0",le="0.0025"} 1` + "\n",
+		`hid_write_latency_seconds_bucket{path="/dev/hidraw0",le="+Inf"} 1` + "\n",
+		`hid_write_latency_seconds_sum{path="/dev/hidraw0"} 0.002` + "\n",
+		`hid_write_latency_seconds_count{path="/dev/hidraw0"} 1` + "\n",
+	} {
+		if !strings.Contains(body, s) {
+			t.Errorf("missing %q in:\n%s", s, body)
+		}
+	}
+}
+
+func TestVar(t *testing.T) {
+	c := NewCollector()
+	trace(c, hid.TraceEvent{Op: hid.OpOpen})
+	var devices []Device
+	if err := json.NewDecoder(bytes.NewBufferString(c.Var().String())).Decode(&devices); err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 1 || devices[0].Path != testPath || !devices[0].Open {
+		t.Errorf("got %+v", devices)
+	}
+}
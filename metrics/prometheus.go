@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// labelEscaper escapes label values in the Prometheus text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// counter describes a per-device metric.
+type counter struct {
+	name  string
+	typ   string
+	help  string
+	value func(d *Device) uint64
+}
+
+var deviceMetrics = []counter{
+	{"hid_device_open", "gauge", "Whether the device is open.", func(d *Device) uint64 {
+		if d.Open {
+			return 1
+		}
+		return 0
+	}},
+	{"hid_device_opens_total", "counter", "Number of times the device was opened.", func(d *Device) uint64 { return d.Opens }},
+	{"hid_reports_read_total", "counter", "Reports read from the device.", func(d *Device) uint64 { return d.ReportsRead }},
+	{"hid_reports_written_total", "counter", "Reports written to the device.", func(d *Device) uint64 { return d.ReportsWritten }},
+	{"hid_read_bytes_total", "counter", "Bytes read from the device.", func(d *Device) uint64 { return d.BytesRead }},
+	{"hid_written_bytes_total", "counter", "Bytes written to the device.", func(d *Device) uint64 { return d.BytesWritten }},
+	{"hid_read_timeouts_total", "counter", "Reads from the device which timed out.", func(d *Device) uint64 { return d.Timeouts }},
+}
+
+// WritePrometheus writes the metrics of each device to w in the Prometheus
+// text exposition format. Each metric is labeled by device path.
+func (c *Collector) WritePrometheus(w io.Writer) error {
+	devices := c.Snapshot()
+	bw := bufio.NewWriter(w)
+
+	for _, m := range deviceMetrics {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.typ)
+		for i := range devices {
+			fmt.Fprintf(bw, "%s{path=\"%s\"} %d\n", m.name, labelEscaper.Replace(devices[i].Path), m.value(&devices[i]))
+		}
+	}
+
+	fmt.Fprintf(bw, "# HELP hid_errors_total Errors returned by operations on the device.\n# TYPE hid_errors_total counter\n")
+	for _, d := range devices {
+		ops := make([]string, 0, len(d.Errors))
+		for op := range d.Errors {
+			ops = append(ops, op)
+		}
+		sort.Strings(ops)
+		for _, op := range ops {
+			for kind := ErrorKind(0); int(kind) < numKinds; kind++ {
+				if n := d.Errors[op][kind.String()]; n > 0 {
+					fmt.Fprintf(bw, "hid_errors_total{path=\"%s\",op=\"%s\",kind=\"%s\"} %d\n",
+						labelEscaper.Replace(d.Path), op, kind, n)
+				}
+			}
+		}
+	}
+
+	writeHistogram(bw, "hid_read_interval_seconds", "Time between successive reads from the device.",
+		devices, func(d *Device) Histogram { return d.ReadInterval })
+	writeHistogram(bw, "hid_write_latency_seconds", "Duration of writes to the device.",
+		devices, func(d *Device) Histogram { return d.WriteLatency })
+	return bw.Flush()
+}
+
+func writeHistogram(w io.Writer, name, help string, devices []Device, fn func(d *Device) Histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	for i := range devices {
+		path := labelEscaper.Replace(devices[i].Path)
+		h := fn(&devices[i])
+		var cum uint64
+		for j, n := range h.Counts {
+			cum += n
+			le := "+Inf"
+			if j < len(h.Bounds) {
+				le = strconv.FormatFloat(h.Bounds[j].Seconds(), 'g', -1, 64)
+			}
+			fmt.Fprintf(w, "%s_bucket{path=\"%s\",le=\"%s\"} %d\n", name, path, le, cum)
+		}
+		fmt.Fprintf(w, "%s_sum{path=\"%s\"} %s\n", name, path, strconv.FormatFloat(h.Sum.Seconds(), 'g', -1, 64))
+		fmt.Fprintf(w, "%s_count{path=\"%s\"} %d\n", name, path, h.Count)
+	}
+}
+
+// Handler returns an http.Handler which serves metrics in the Prometheus
+// text exposition format.
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		c.WritePrometheus(w)
+	})
+}
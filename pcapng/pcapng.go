@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package pcapng
+
+import (
+	"io"
+	"time"
+)
+
+// LinkType is the link type written to pcapng files (LINKTYPE_USB_LINUX_MMAPPED).
+const LinkType = 220
+
+// Block types.
+const (
+	blockSHB = 0x0a0d0d0a // Section Header Block
+	blockIDB = 0x00000001 // Interface Description Block
+	blockEPB = 0x00000006 // Enhanced Packet Block
+)
+
+// Option codes.
+const (
+	optEnd         = 0
+	optSHBUserAppl = 4
+	optIFName      = 2
+	optIFDesc      = 3
+	optEPBFlags    = 2
+)
+
+// EPB flags indicating the direction of a packet.
+const (
+	flagInbound  = 1
+	flagOutbound = 2
+)
+
+// pcapng files and usbmon headers are written in little-endian byte order.
+
+func append16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+func append32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func append64(b []byte, v uint64) []byte {
+	return append32(append32(b, uint32(v)), uint32(v>>32))
+}
+
+// option is a pcapng option.
+type option struct {
+	code  uint16
+	value []byte
+}
+
+// appendOptions appends opts, followed by an end of options marker if any
+// options are present.
+func appendOptions(b []byte, opts []option) []byte {
+	if len(opts) == 0 {
+		return b
+	}
+	for _, opt := range opts {
+		b = append16(b, opt.code)
+		b = append16(b, uint16(len(opt.value)))
+		b = append(b, opt.value...)
+		b = pad(b)
+	}
+	return append32(b, optEnd)
+}
+
+// pad pads b with zeros to a multiple of 4 bytes.
+func pad(b []byte) []byte {
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// writeBlock writes a block of type typ with the given body to w.
+func writeBlock(w io.Writer, typ uint32, body []byte) error {
+	n := uint32(12 + len(body))
+	b := make([]byte, 0, n)
+	b = append32(b, typ)
+	b = append32(b, n)
+	b = append(b, body...)
+	b = append32(b, n)
+	_, err := w.Write(b)
+	return err
+}
+
+// writeSHB writes a Section Header Block to w.
+func writeSHB(w io.Writer, opts ...option) error {
+	var b []byte
+	b = append32(b, 0x1a2b3c4d) // Byte-Order Magic
+	b = append16(b, 1)          // Major Version
+	b = append16(b, 0)          // Minor Version
+	b = append64(b, ^uint64(0)) // Section Length (unspecified)
+	return writeBlock(w, blockSHB, appendOptions(b, opts))
+}
+
+// writeIDB writes an Interface Description Block to w. Timestamps have the
+// default resolution of microseconds.
+func writeIDB(w io.Writer, opts ...option) error {
+	var b []byte
+	b = append16(b, LinkType)
+	b = append16(b, 0) // Reserved
+	b = append32(b, 0) // Snap Length (unlimited)
+	return writeBlock(w, blockIDB, appendOptions(b, opts))
+}
+
+// writeEPB writes an Enhanced Packet Block containing data captured at time t
+// to w.
+func writeEPB(w io.Writer, t time.Time, data []byte, opts ...option) error {
+	ts := uint64(t.UnixNano() / int64(time.Microsecond))
+	var b []byte
+	b = append32(b, 0) // Interface ID
+	b = append32(b, uint32(ts>>32))
+	b = append32(b, uint32(ts))
+	b = append32(b, uint32(len(data))) // Captured Packet Length
+	b = append32(b, uint32(len(data))) // Original Packet Length
+	b = pad(append(b, data...))
+	return writeBlock(w, blockEPB, appendOptions(b, opts))
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package pcapng
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/sstallion/go-hid"
+	"github.com/sstallion/go-hid/hidtest"
+)
+
+// testDesc describes a vendor-defined device with an 8-byte input report
+// (ID 1), an 8-byte output report (ID 2), and an 8-byte feature report (ID 3).
+var testDesc = []byte{
+	0x06, 0x00, 0xff, // Usage Page (Vendor Defined 0xFF00)
+	0x09, 0x01, // Usage (0x01)
+	0xa1, 0x01, // Collection (Application)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x26, 0xff, 0x00, //   Logical Maximum (255)
+	0x75, 0x08, //   Report Size (8)
+	0x95, 0x08, //   Report Count (8)
+	0x85, 0x01, //   Report ID (1)
+	0x09, 0x01, //   Usage (0x01)
+	0x81, 0x02, //   Input (Data,Var,Abs)
+	0x85, 0x02, //   Report ID (2)
+	0x09, 0x01, //   Usage (0x01)
+	0x91, 0x02, //   Output (Data,Var,Abs)
+	0x85, 0x03, //   Report ID (3)
+	0x09, 0x01, //   Usage (0x01)
+	0xb1, 0x02, //   Feature (Data,Var,Abs)
+	0xc0, //       End Collection
+}
+
+// block is a pcapng block.
+type block struct {
+	typ  uint32
+	body []byte
+}
+
+// parseBlocks splits a pcapng file into blocks.
+func parseBlocks(t *testing.T, b []byte) []block {
+	t.Helper()
+	var blocks []block
+	for len(b) > 0 {
+		if len(b) < 12 {
+			t.Fatalf("truncated block: %x", b)
+		}
+		n := binary.LittleEndian.Uint32(b[4:])
+		if n%4 != 0 || int(n) > len(b) || binary.LittleEndian.Uint32(b[n-4:]) != n {
+			t.Fatalf("invalid block length: %d", n)
+		}
+		blocks = append(blocks, block{binary.LittleEndian.Uint32(b), b[8 : n-4]})
+		b = b[n:]
+	}
+	return blocks
+}
+
+// packet returns the usbmon packet contained in an Enhanced Packet Block.
+func (b block) packet() []byte {
+	n := binary.LittleEndian.Uint32(b.body[12:])
+	return b.body[20 : 20+n]
+}
+
+func TestTracer(t *testing.T) {
+	s, err := hidtest.NewSimulator(hid.DeviceInfo{VendorID: 0x1209, ProductID: 0x0001}, testDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.OnGetFeature(3, func() []byte { return []byte{3, 1, 2, 3, 4, 5, 6, 7, 8} })
+
+	var buf bytes.Buffer
+	tr, err := NewTracer(s, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := []byte{2, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0x10, 0x11}
+	if _, err := tr.Write(output); err != nil {
+		t.Fatal(err)
+	}
+	input := []byte{1, 8, 7, 6, 5, 4, 3, 2, 1}
+	s.Send(input)
+	p := make([]byte, 65)
+	if _, err := tr.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	p[0] = 3
+	if _, err := tr.GetFeatureReport(p); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tr.ReadWithTimeout(p, 0); err != hid.ErrTimeout {
+		t.Fatalf("got %v, want %v", err, hid.ErrTimeout)
+	}
+	if err := tr.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := parseBlocks(t, buf.Bytes())
+	if len(blocks) != 2+2*6 {
+		t.Fatalf("got %d blocks, want %d", len(blocks), 2+2*6)
+	}
+	if blocks[0].typ != blockSHB || binary.LittleEndian.Uint32(blocks[0].body) != 0x1a2b3c4d {
+		t.Errorf("invalid section header block")
+	}
+	if blocks[1].typ != blockIDB || binary.LittleEndian.Uint16(blocks[1].body) != LinkType {
+		t.Errorf("invalid interface description block")
+	}
+
+	// The report descriptor is returned by the third GET_DESCRIPTOR request.
+	pkt := blocks[6].packet()
+	if want := setupPacket(0x81, reqGetDescriptor, descReport<<8, 0, uint16(len(testDesc))); !bytes.Equal(pkt[40:48], want) {
+		t.Errorf("got setup %x, want %x", pkt[40:48], want)
+	}
+	if pkt := blocks[7].packet(); !bytes.Equal(pkt[usbmonHeaderSize:], testDesc) {
+		t.Errorf("got report descriptor %x, want %x", pkt[usbmonHeaderSize:], testDesc)
+	}
+
+	for _, tt := range []struct {
+		block int
+		typ   byte
+		xfer  byte
+		ep    byte
+		data  []byte
+	}{
+		{8, urbSubmit, xferInterrupt, epInterruptOut, output},
+		{11, urbComplete, xferInterrupt, epInterruptIn, input},
+		{13, urbComplete, xferControl, epControlIn, []byte{3, 1, 2, 3, 4, 5, 6, 7, 8}},
+	} {
+		pkt := blocks[tt.block].packet()
+		if pkt[8] != tt.typ || pkt[9] != tt.xfer || pkt[10] != tt.ep {
+			t.Errorf("block %d: got %c/%d/%#02x, want %c/%d/%#02x", tt.block,
+				pkt[8], pkt[9], pkt[10], tt.typ, tt.xfer, tt.ep)
+		}
+		if data := pkt[usbmonHeaderSize:]; !bytes.Equal(data, tt.data) {
+			t.Errorf("block %d: got data %x, want %x", tt.block, data, tt.data)
+		}
+	}
+}
+
+func TestTracerUnnumbered(t *testing.T) {
+	desc := []byte{
+		0x06, 0x00, 0xff, 0x09, 0x01, 0xa1, 0x01, 0x75, 0x08, 0x95, 0x02,
+		0x09, 0x01, 0x91, 0x02, 0xc0,
+	}
+	s, err := hidtest.NewSimulator(hid.DeviceInfo{}, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	tr, err := NewTracer(s, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tr.Write([]byte{0, 0xa, 0xb}); err != nil {
+		t.Fatal(err)
+	}
+	blocks := parseBlocks(t, buf.Bytes())
+	pkt := blocks[len(blocks)-2].packet()
+	if data, want := pkt[usbmonHeaderSize:], []byte{0xa, 0xb}; !bytes.Equal(data, want) {
+		t.Errorf("got data %x, want %x", data, want)
+	}
+}
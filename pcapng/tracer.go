@@ -0,0 +1,297 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package pcapng
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// Tracer is a hid.DeviceIO which writes each report sent to and received from
+// another hid.DeviceIO to a pcapng file. Transfers are written once they
+// complete, so packets may not appear in timestamp order if operations are
+// performed concurrently.
+type Tracer struct {
+	dev      hid.DeviceIO
+	numbered bool // Reports Prefixed by Report ID
+
+	mu  sync.Mutex
+	w   io.Writer
+	id  uint64 // last URB ID
+	err error
+}
+
+// NewTracer returns a new Tracer which traces reports sent to and received
+// from dev to w. The report descriptor of dev is written to w before any
+// reports; if it cannot be read, reports are assumed to be numbered.
+func NewTracer(dev hid.DeviceIO, w io.Writer) (*Tracer, error) {
+	t := &Tracer{dev: dev, w: w, numbered: true}
+
+	info, err := dev.GetDeviceInfo()
+	if err != nil {
+		info = &hid.DeviceInfo{}
+	}
+	desc := make([]byte, hid.MaxReportDescriptorSize)
+	n, err := dev.GetReportDescriptor(desc)
+	if err != nil {
+		desc = nil
+	} else {
+		desc = desc[:n]
+		if sizes, err := hid.ParseReportSizes(desc); err == nil {
+			t.numbered = sizes.Numbered()
+		}
+	}
+
+	if err := writeSHB(w, option{optSHBUserAppl, []byte("go-hid")}); err != nil {
+		return nil, err
+	}
+	opts := []option{{optIFName, []byte("usbmon1")}}
+	if info.Path != "" {
+		opts = append(opts, option{optIFDesc, []byte(info.Path)})
+	}
+	if err := writeIDB(w, opts...); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	t.control(now, now, 0x80, reqGetDescriptor, descDevice<<8, 18,
+		deviceDescriptor(info.VendorID, info.ProductID, info.ReleaseNbr), nil)
+	config := configDescriptor(len(desc))
+	t.control(now, now, 0x80, reqGetDescriptor, descConfig<<8, len(config), config, nil)
+	if desc != nil {
+		t.control(now, now, 0x81, reqGetDescriptor, descReport<<8, len(desc), desc, nil)
+	}
+	if err := t.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Err returns the first error that occurred writing the trace.
+func (t *Tracer) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// wire returns report as transferred over the bus; the report ID is omitted
+// for devices which do not use numbered reports.
+func (t *Tracer) wire(report []byte) []byte {
+	if !t.numbered && len(report) > 0 {
+		return report[1:]
+	}
+	return report
+}
+
+// write writes the usbmon packet describing u.
+func (t *Tracer) write(u *urb) {
+	flags := uint32(flagOutbound)
+	if u.typ == urbComplete {
+		flags = flagInbound
+	}
+	if err := writeEPB(t.w, u.time, u.packet(), option{optEPBFlags, append32(nil, flags)}); err != nil && t.err == nil {
+		t.err = err
+	}
+}
+
+// transfer writes the submission and completion of a transfer to endpoint ep
+// which started at start and ended at end. For OUT transfers, data is
+// submitted; for IN transfers, length bytes are requested and data is
+// returned on completion.
+func (t *Tracer) transfer(start, end time.Time, xfer, ep byte, setup []byte, length int, data []byte, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.id++
+	submit := &urb{id: t.id, typ: urbSubmit, xfer: xfer, ep: ep, setup: setup,
+		status: statusInProgress, length: length, time: start}
+	complete := &urb{id: t.id, typ: urbComplete, xfer: xfer, ep: ep, length: len(data), time: end}
+	if ep&0x80 == 0 {
+		submit.data = data
+	} else if err == nil {
+		complete.data = data
+	}
+	if err != nil {
+		complete.status = statusError
+		complete.length = 0
+	}
+	t.write(submit)
+	t.write(complete)
+}
+
+// control writes a control transfer with the given request.
+func (t *Tracer) control(start, end time.Time, requestType, request byte, value uint16, length int, data []byte, err error) {
+	ep := byte(epControlOut)
+	if requestType&0x80 != 0 {
+		ep = epControlIn
+	}
+	setup := setupPacket(requestType, request, value, 0, uint16(length))
+	t.transfer(start, end, xferControl, ep, setup, length, data, err)
+}
+
+// setReport performs fn and writes a SET_REPORT transfer for the report p of
+// type typ.
+func (t *Tracer) setReport(typ hid.ReportType, p []byte, fn func([]byte) (int, error)) (int, error) {
+	start := time.Now()
+	n, err := fn(p)
+	if len(p) > 0 {
+		data := t.wire(p)
+		t.control(start, time.Now(), 0x21, reqSetReport, reportValue(typ, p[0]), len(data), data, err)
+	}
+	return n, err
+}
+
+// getReport performs fn and writes a GET_REPORT transfer for the report p of
+// type typ.
+func (t *Tracer) getReport(typ hid.ReportType, p []byte, fn func([]byte) (int, error)) (int, error) {
+	if len(p) == 0 {
+		return fn(p)
+	}
+	id := p[0]
+	start := time.Now()
+	n, err := fn(p)
+	var data []byte
+	if n > 0 {
+		data = t.wire(p[:n])
+	}
+	t.control(start, time.Now(), 0xa1, reqGetReport, reportValue(typ, id), len(t.wire(p)), data, err)
+	return n, err
+}
+
+// reportValue returns the wValue of a GET_REPORT or SET_REPORT request.
+func reportValue(typ hid.ReportType, id byte) uint16 {
+	var rtype uint16
+	switch typ {
+	case hid.InputReport:
+		rtype = 1
+	case hid.OutputReport:
+		rtype = 2
+	case hid.FeatureReport:
+		rtype = 3
+	}
+	return rtype<<8 | uint16(id)
+}
+
+// read writes an interrupt IN transfer for a report read by fn.
+func (t *Tracer) read(p []byte, fn func([]byte) (int, error)) (int, error) {
+	start := time.Now()
+	n, err := fn(p)
+	if errors.Is(err, hid.ErrTimeout) || (err == nil && n <= 0) {
+		return n, err
+	}
+	var data []byte
+	if n > 0 {
+		data = p[:n]
+	}
+	t.transfer(start, time.Now(), xferInterrupt, epInterruptIn, nil, len(p), data, err)
+	return n, err
+}
+
+// Write writes an output report to the device.
+func (t *Tracer) Write(p []byte) (int, error) {
+	start := time.Now()
+	n, err := t.dev.Write(p)
+	data := t.wire(p)
+	t.transfer(start, time.Now(), xferInterrupt, epInterruptOut, nil, len(data), data, err)
+	return n, err
+}
+
+// ReadWithTimeout reads an input report from the device. Reads which time
+// out are not traced.
+func (t *Tracer) ReadWithTimeout(p []byte, timeout time.Duration) (int, error) {
+	return t.read(p, func(p []byte) (int, error) {
+		return t.dev.ReadWithTimeout(p, timeout)
+	})
+}
+
+// Read reads an input report from the device. Reads which time out are not
+// traced.
+func (t *Tracer) Read(p []byte) (int, error) {
+	return t.read(p, t.dev.Read)
+}
+
+// SetNonblock sets the nonblocking state of the device.
+func (t *Tracer) SetNonblock(nonblocking bool) error {
+	return t.dev.SetNonblock(nonblocking)
+}
+
+// SendFeatureReport sends a feature report to the device.
+func (t *Tracer) SendFeatureReport(p []byte) (int, error) {
+	return t.setReport(hid.FeatureReport, p, t.dev.SendFeatureReport)
+}
+
+// GetFeatureReport gets a feature report from the device.
+func (t *Tracer) GetFeatureReport(p []byte) (int, error) {
+	return t.getReport(hid.FeatureReport, p, t.dev.GetFeatureReport)
+}
+
+// GetInputReport gets an input report from the device.
+func (t *Tracer) GetInputReport(p []byte) (int, error) {
+	return t.getReport(hid.InputReport, p, t.dev.GetInputReport)
+}
+
+// SendOutputReport sends an output report to the device.
+func (t *Tracer) SendOutputReport(p []byte) (int, error) {
+	return t.setReport(hid.OutputReport, p, t.dev.SendOutputReport)
+}
+
+// Close closes the device. The underlying writer is not closed.
+func (t *Tracer) Close() error {
+	return t.dev.Close()
+}
+
+// GetMfrStr returns the manufacturer string of the device.
+func (t *Tracer) GetMfrStr() (string, error) {
+	return t.dev.GetMfrStr()
+}
+
+// GetProductStr returns the product string of the device.
+func (t *Tracer) GetProductStr() (string, error) {
+	return t.dev.GetProductStr()
+}
+
+// GetSerialNbr returns the serial number of the device.
+func (t *Tracer) GetSerialNbr() (string, error) {
+	return t.dev.GetSerialNbr()
+}
+
+// GetIndexedStr returns a string descriptor from the device.
+func (t *Tracer) GetIndexedStr(index int) (string, error) {
+	return t.dev.GetIndexedStr(index)
+}
+
+// GetDeviceInfo returns information about the device.
+func (t *Tracer) GetDeviceInfo() (*hid.DeviceInfo, error) {
+	return t.dev.GetDeviceInfo()
+}
+
+// GetReportDescriptor returns the report descriptor of the device.
+func (t *Tracer) GetReportDescriptor(p []byte) (int, error) {
+	return t.dev.GetReportDescriptor(p)
+}
+
+var _ hid.DeviceIO = (*Tracer)(nil)
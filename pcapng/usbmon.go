@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package pcapng
+
+import "time"
+
+// URB types.
+const (
+	urbSubmit   = 'S'
+	urbComplete = 'C'
+)
+
+// Transfer types.
+const (
+	xferInterrupt = 1
+	xferControl   = 2
+)
+
+// Endpoint addresses.
+const (
+	epControlOut   = 0x00
+	epControlIn    = 0x80
+	epInterruptIn  = 0x81
+	epInterruptOut = 0x02
+)
+
+// URB status values.
+const (
+	statusInProgress = -115 // -EINPROGRESS
+	statusError      = -71  // -EPROTO
+)
+
+// Bus and device numbers of the traced device.
+const (
+	busNum = 1
+	devNum = 1
+)
+
+// usbmonHeaderSize is the size of the usbmon packet header.
+const usbmonHeaderSize = 64
+
+// urb describes a USB request block written as a usbmon packet.
+type urb struct {
+	id     uint64 // URB ID
+	typ    byte   // URB Type (submit or complete)
+	xfer   byte   // Transfer Type
+	ep     byte   // Endpoint Address
+	setup  []byte // Setup Packet (control submissions only)
+	status int32  // URB Status
+	length int    // Requested or Transferred Length
+	data   []byte // Data
+	time   time.Time
+}
+
+// inbound reports whether the packet carries data towards the host.
+func (u *urb) inbound() bool {
+	return u.ep&0x80 != 0
+}
+
+// packet returns the usbmon packet (header and data) describing u.
+func (u *urb) packet() []byte {
+	b := make([]byte, 0, usbmonHeaderSize+len(u.data))
+	b = append64(b, u.id)
+	b = append(b, u.typ, u.xfer, u.ep, devNum)
+	b = append16(b, busNum)
+
+	// Setup and data flags are 0 if present.
+	if u.setup != nil {
+		b = append(b, 0)
+	} else {
+		b = append(b, '-')
+	}
+	switch {
+	case len(u.data) > 0:
+		b = append(b, 0)
+	case u.inbound():
+		b = append(b, '<')
+	default:
+		b = append(b, '>')
+	}
+
+	us := u.time.UnixNano() / int64(time.Microsecond)
+	b = append64(b, uint64(us/1e6))
+	b = append32(b, uint32(us%1e6))
+	b = append32(b, uint32(u.status))
+	b = append32(b, uint32(u.length))
+	b = append32(b, uint32(len(u.data)))
+	setup := make([]byte, 8)
+	copy(setup, u.setup)
+	b = append(b, setup...)
+	if u.xfer == xferInterrupt {
+		b = append32(b, 1) // Interval
+	} else {
+		b = append32(b, 0)
+	}
+	b = append32(b, 0) // Start Frame
+	b = append32(b, 0) // Transfer Flags
+	b = append32(b, 0) // Number of ISO Descriptors
+	return append(b, u.data...)
+}
+
+// setupPacket returns a control setup packet.
+func setupPacket(requestType, request byte, value, index, length uint16) []byte {
+	b := []byte{requestType, request}
+	b = append16(b, value)
+	b = append16(b, index)
+	return append16(b, length)
+}
+
+// Standard and HID class requests.
+const (
+	reqGetDescriptor = 0x06
+	reqGetReport     = 0x01
+	reqSetReport     = 0x09
+)
+
+// Descriptor types.
+const (
+	descDevice = 0x01
+	descConfig = 0x02
+	descHID    = 0x21
+	descReport = 0x22
+)
+
+// deviceDescriptor returns a USB device descriptor.
+func deviceDescriptor(vid, pid, release uint16) []byte {
+	b := []byte{18, descDevice}
+	b = append16(b, 0x0200)  // bcdUSB
+	b = append(b, 0, 0, 0)   // bDeviceClass, bDeviceSubClass, bDeviceProtocol
+	b = append(b, 64)        // bMaxPacketSize0
+	b = append16(b, vid)     // idVendor
+	b = append16(b, pid)     // idProduct
+	b = append16(b, release) // bcdDevice
+	return append(b, 0, 0, 0, 1)
+}
+
+// configDescriptor returns a USB configuration descriptor describing a single
+// HID interface with an interrupt IN and OUT endpoint, and a report
+// descriptor of length descLen.
+func configDescriptor(descLen int) []byte {
+	b := []byte{9, descConfig}
+	b = append16(b, 9+9+9+7+7)                  // wTotalLength
+	b = append(b, 1, 1, 0, 0x80, 50)            // bNumInterfaces, bConfigurationValue, iConfiguration, bmAttributes, bMaxPower
+	b = append(b, 9, 0x04, 0, 0, 2, 3, 0, 0, 0) // Interface: HID, 2 endpoints
+	b = append(b, 9, descHID)
+	b = append16(b, 0x0111)          // bcdHID
+	b = append(b, 0, 1, descReport)  // bCountryCode, bNumDescriptors, bDescriptorType
+	b = append16(b, uint16(descLen)) // wDescriptorLength
+	b = append(b, 7, 0x05, epInterruptIn, 0x03)
+	b = append16(b, 64)
+	b = append(b, 1)
+	b = append(b, 7, 0x05, epInterruptOut, 0x03)
+	b = append16(b, 64)
+	return append(b, 1)
+}
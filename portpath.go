@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// libusbPathRegexp matches device paths used by the libusb backend, which
+// are formatted as bus-port.port:config.interface (e.g. 1-2.4.1:1.0).
+var libusbPathRegexp = regexp.MustCompile(`^\d+-\d+(\.\d+)*:\d+\.\d+$`)
+
+// portPath returns the USB bus and port path of the device with the given
+// path, or an empty string if it is unavailable.
+func portPath(path string) string {
+	if libusbPathRegexp.MatchString(path) {
+		// The path is formatted using libusb_get_port_numbers.
+		return path[:strings.IndexByte(path, ':')]
+	}
+	return sysPortPath(path)
+}
+
+// PortPath returns the USB bus and port path of the device (e.g. 1-2.4.1)
+// and an error, if any. The port path identifies the physical port, including
+// any hubs, to which the device is attached; it is stable across reconnects
+// and reboots as long as the topology of the bus does not change.
+//
+// Port paths are available on Linux for USB devices and on all platforms
+// supported by the libusb backend.
+func (d *Device) PortPath() (string, error) {
+	info, err := d.GetDeviceInfo()
+	if err != nil {
+		return "", err
+	}
+	if info.PortPath == "" {
+		return "", errors.New("port path not available")
+	}
+	return info.PortPath, nil
+}
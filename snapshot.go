@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DeviceID is a stable identity for a HID device. Identities are derived from
+// device information that is not expected to change over the lifetime of a
+// device, such as across firmware updates or reconnection. If a device does
+// not report a serial number, its port path is used to distinguish otherwise
+// identical devices; if the port path is not known, its device path is used
+// instead, which may change when the device is reconnected.
+type DeviceID struct {
+	BusType      BusType // Underlying Bus Type
+	VendorID     uint16  // Device Vendor ID
+	ProductID    uint16  // Device Product ID
+	SerialNbr    string  // Serial Number
+	PortPath     string  // Physical Port Path (if no Serial Number)
+	Path         string  // Platform-Specific Device Path (if no Serial Number or Port Path)
+	InterfaceNbr int     // USB Interface Number
+	UsagePage    uint16  // Usage Page for Device/Interface
+	Usage        uint16  // Usage for Device/Interface
+}
+
+// ID returns the identity of the device described by info.
+func (info *DeviceInfo) ID() DeviceID {
+	id := DeviceID{
+		BusType:      info.BusType,
+		VendorID:     info.VendorID,
+		ProductID:    info.ProductID,
+		SerialNbr:    info.SerialNbr,
+		InterfaceNbr: info.InterfaceNbr,
+		UsagePage:    info.UsagePage,
+		Usage:        info.Usage,
+	}
+	switch {
+	case id.SerialNbr != "":
+	case info.PortPath != "":
+		id.PortPath = info.PortPath
+	default:
+		id.Path = info.Path
+	}
+	return id
+}
+
+// String returns a string representation of the identity.
+func (id DeviceID) String() string {
+	s := fmt.Sprintf("%s:%04x:%04x", id.BusType, id.VendorID, id.ProductID)
+	switch {
+	case id.SerialNbr != "":
+		s += "/" + id.SerialNbr
+	case id.PortPath != "":
+		s += "@" + id.PortPath
+	default:
+		s += "@" + id.Path
+	}
+	return s + fmt.Sprintf("#%d:%04x:%04x", id.InterfaceNbr, id.UsagePage, id.Usage)
+}
+
+// Inventory is an immutable list of HID devices attached to the system at a
+// point in time.
+type Inventory struct {
+	time    time.Time
+	devices []DeviceInfo
+}
+
+// Snapshot enumerates all HID devices attached to the system. It returns an
+// Inventory and an error, if any.
+func Snapshot() (*Inventory, error) {
+	var devices []DeviceInfo
+	t := time.Now()
+	err := Enumerate(VendorIDAny, ProductIDAny, func(info *DeviceInfo) error {
+		devices = append(devices, *info)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Inventory{time: t, devices: devices}, nil
+}
+
+// NewInventory returns an Inventory containing a copy of devices at time t.
+// It may be used to describe the devices expected to be attached to the
+// system.
+func NewInventory(t time.Time, devices []DeviceInfo) *Inventory {
+	return &Inventory{time: t, devices: copyDevices(devices)}
+}
+
+// Time returns the time at which the Inventory was taken.
+func (inv *Inventory) Time() time.Time {
+	return inv.time
+}
+
+// Len returns the number of devices in the Inventory.
+func (inv *Inventory) Len() int {
+	return len(inv.devices)
+}
+
+// Devices returns a copy of the devices in the Inventory in the order in
+// which they were enumerated.
+func (inv *Inventory) Devices() []DeviceInfo {
+	return copyDevices(inv.devices)
+}
+
+// Lookup returns the device in the Inventory with a matching identity and a
+// boolean indicating whether the device was found.
+func (inv *Inventory) Lookup(id DeviceID) (DeviceInfo, bool) {
+	for i := range inv.devices {
+		if inv.devices[i].ID() == id {
+			return copyDevice(inv.devices[i]), true
+		}
+	}
+	return DeviceInfo{}, false
+}
+
+type inventoryJSON struct {
+	Time    time.Time    `json:"time"`
+	Devices []DeviceInfo `json:"devices"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. An Inventory is
+// encoded as a JSON object containing the time at which it was taken in RFC
+// 3339 format and an array of devices, each encoded as described by
+// DeviceInfo.MarshalJSON:
+//
+//	{"time": "2026-01-02T15:04:05Z", "devices": [...]}
+func (inv *Inventory) MarshalJSON() ([]byte, error) {
+	v := inventoryJSON{Time: inv.time, Devices: inv.devices}
+	if v.Devices == nil {
+		v.Devices = []DeviceInfo{}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (inv *Inventory) UnmarshalJSON(b []byte) error {
+	var v inventoryJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	inv.time, inv.devices = v.Time, v.Devices
+	return nil
+}
+
+// Change describes a device present in two inventories whose information
+// differs.
+type Change struct {
+	Old    DeviceInfo `json:"old"`    // Old Device Information
+	New    DeviceInfo `json:"new"`    // New Device Information
+	Fields []string   `json:"fields"` // Names of Changed DeviceInfo Fields
+}
+
+// Delta describes the differences between two inventories.
+type Delta struct {
+	Added   []DeviceInfo `json:"added"`   // Devices Only Present in New Inventory
+	Removed []DeviceInfo `json:"removed"` // Devices Only Present in Old Inventory
+	Changed []Change     `json:"changed"` // Devices Present in Both Inventories
+}
+
+// Empty reports whether the inventories compared were equivalent.
+func (d *Delta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the inventories old and new and returns the devices that were
+// added, removed, or changed. Devices are matched by identity; see DeviceID
+// for details. Devices that are unchanged are not reported.
+func Diff(old, new *Inventory) *Delta {
+	d := &Delta{}
+
+	// Devices sharing an identity are matched in enumeration order.
+	remaining := make(map[DeviceID][]DeviceInfo)
+	for _, info := range old.devices {
+		id := info.ID()
+		remaining[id] = append(remaining[id], info)
+	}
+	for _, info := range new.devices {
+		id := info.ID()
+		candidates := remaining[id]
+		if len(candidates) == 0 {
+			d.Added = append(d.Added, copyDevice(info))
+			continue
+		}
+		remaining[id] = candidates[1:]
+		if fields := changedFields(&candidates[0], &info); len(fields) > 0 {
+			d.Changed = append(d.Changed, Change{
+				Old:    copyDevice(candidates[0]),
+				New:    copyDevice(info),
+				Fields: fields,
+			})
+		}
+	}
+	for _, info := range old.devices {
+		id := info.ID()
+		if candidates := remaining[id]; len(candidates) > 0 {
+			d.Removed = append(d.Removed, copyDevice(candidates[0]))
+			remaining[id] = candidates[1:]
+		}
+	}
+	return d
+}
+
+// changedFields returns the names of the DeviceInfo fields that differ
+// between a and b.
+func changedFields(a, b *DeviceInfo) []string {
+	var fields []string
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for i := 0; i < va.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			fields = append(fields, va.Type().Field(i).Name)
+		}
+	}
+	return fields
+}
+
+// copyDevice returns a deep copy of info.
+func copyDevice(info DeviceInfo) DeviceInfo {
+	info.Collections = copyCollections(info.Collections)
+	return info
+}
+
+// copyDevices returns a deep copy of devices.
+func copyDevices(devices []DeviceInfo) []DeviceInfo {
+	if devices == nil {
+		return nil
+	}
+	c := make([]DeviceInfo, len(devices))
+	for i, info := range devices {
+		c[i] = copyDevice(info)
+	}
+	return c
+}
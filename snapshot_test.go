@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	keyboard := DeviceInfo{Path: "/dev/hidraw0", VendorID: 0x046d, ProductID: 0xc52b, ReleaseNbr: 0x1211, BusType: BusUSB}
+	fixture := DeviceInfo{Path: "/dev/hidraw1", VendorID: 0x04d8, ProductID: 0x003f, SerialNbr: "A1", ReleaseNbr: 0x0100, BusType: BusUSB}
+	updated := fixture
+	updated.Path = "/dev/hidraw2"
+	updated.ReleaseNbr = 0x0200
+	other := DeviceInfo{Path: "/dev/hidraw3", VendorID: 0x04d8, ProductID: 0x003f, SerialNbr: "A2", BusType: BusUSB}
+
+	old := NewInventory(time.Unix(0, 0), []DeviceInfo{keyboard, fixture})
+	new := NewInventory(time.Unix(1, 0), []DeviceInfo{updated, other})
+
+	got := Diff(old, new)
+	want := &Delta{
+		Added:   []DeviceInfo{other},
+		Removed: []DeviceInfo{keyboard},
+		Changed: []Change{
+			{Old: fixture, New: updated, Fields: []string{"Path", "ReleaseNbr"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got.Empty() {
+		t.Error("expected non-empty delta")
+	}
+	if d := Diff(new, new); !d.Empty() {
+		t.Errorf("expected empty delta, got %+v", d)
+	}
+}
+
+func TestDiffPortPath(t *testing.T) {
+	a := DeviceInfo{Path: "/dev/hidraw0", VendorID: 0x046d, ProductID: 0xc52b, BusType: BusUSB, PortPath: "1-2"}
+	b := DeviceInfo{Path: "/dev/hidraw1", VendorID: 0x046d, ProductID: 0xc52b, BusType: BusUSB, PortPath: "1-3"}
+	replugged := a
+	replugged.Path = "/dev/hidraw2"
+
+	old := NewInventory(time.Unix(0, 0), []DeviceInfo{a, b})
+	new := NewInventory(time.Unix(1, 0), []DeviceInfo{b, replugged})
+
+	got := Diff(old, new)
+	want := &Delta{
+		Changed: []Change{
+			{Old: a, New: replugged, Fields: []string{"Path"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if id := a.ID(); id.Path != "" || id.String() != "USB:046d:c52b@1-2#0:0000:0000" {
+		t.Errorf("got identity %+v (%s)", id, id)
+	}
+}
+
+func TestInventoryImmutable(t *testing.T) {
+	devices := []DeviceInfo{testDeviceInfo}
+	inv := NewInventory(time.Now(), devices)
+	devices[0].Path = "modified"
+	inv.Devices()[0].Collections[1].ReportIDs[0] = 0xff
+
+	got, ok := inv.Lookup(testDeviceInfo.ID())
+	if !ok {
+		t.Fatal("device not found")
+	}
+	if !reflect.DeepEqual(got, testDeviceInfo) {
+		t.Errorf("got %+v, want %+v", got, testDeviceInfo)
+	}
+}
+
+func TestInventoryJSON(t *testing.T) {
+	want := NewInventory(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC), []DeviceInfo{testDeviceInfo})
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &Inventory{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Time().Equal(want.Time()) || !reflect.DeepEqual(got.Devices(), want.Devices()) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
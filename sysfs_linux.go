@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sysfsRoot is the mount point of sysfs. It is a variable so that tests may
+// substitute a fake sysfs tree.
+var sysfsRoot = "/sys"
+
+// sysfsPath returns the path of elem relative to sysfsRoot.
+func sysfsPath(elem ...string) string {
+	return filepath.Join(append([]string{sysfsRoot}, elem...)...)
+}
+
+// sysfsAttr returns the value of the sysfs attribute name in the device
+// directory dir with trailing whitespace removed. If the attribute does not
+// exist, an empty string is returned.
+func sysfsAttr(dir, name string) string {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(b), "\n")
+}
+
+// sysfsUevent parses the uevent attribute in the device directory dir and
+// returns its KEY=value pairs.
+func sysfsUevent(dir string) map[string]string {
+	m := make(map[string]string)
+	for _, line := range strings.Split(sysfsAttr(dir, "uevent"), "\n") {
+		if i := strings.IndexByte(line, '='); i > 0 {
+			m[line[:i]] = line[i+1:]
+		}
+	}
+	return m
+}
+
+// sysfsLink returns the base name of the target of the symbolic link name in
+// the device directory dir. If the link does not exist, an empty string is
+// returned.
+func sysfsLink(dir, name string) string {
+	target, err := os.Readlink(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(target)
+}
+
+// sysfsParent returns the first ancestor of the device directory dir with a
+// matching subsystem and devtype. If devtype is empty, any devtype matches.
+// If no ancestor matches, an empty string is returned.
+func sysfsParent(dir, subsystem, devtype string) string {
+	devices := sysfsPath("devices")
+	for dir = filepath.Dir(dir); strings.HasPrefix(dir, devices+"/"); dir = filepath.Dir(dir) {
+		if sysfsLink(dir, "subsystem") != subsystem {
+			continue
+		}
+		if devtype == "" || sysfsUevent(dir)["DEVTYPE"] == devtype {
+			return dir
+		}
+	}
+	return ""
+}
+
+// isHIDDevName reports whether name is the name of a HID device as assigned
+// by the kernel, for example 0003:046D:C52B.0001.
+func isHIDDevName(name string) bool {
+	var bus, vid, pid, id uint32
+	n, _ := fmt.Sscanf(name, "%4X:%4X:%4X.%4X", &bus, &vid, &pid, &id)
+	return n == 4 && len(name) == len("0000:0000:0000.0000")
+}
+
+// hidSysfsDir returns the sysfs directory of the HID device with the given
+// path. Both hidraw device nodes (/dev/hidrawN) and libusb paths
+// (bus-port.port:config.interface) are supported.
+func hidSysfsDir(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("empty device path")
+	}
+	if strings.HasPrefix(path, "/dev/") {
+		dir, err := filepath.EvalSymlinks(sysfsPath("class", "hidraw", filepath.Base(path), "device"))
+		if err != nil {
+			return "", err
+		}
+		return dir, nil
+	}
+
+	// libusb paths name the USB interface; the HID device is a child of
+	// the interface named after its bus type, vendor, and product ID.
+	intf, err := filepath.EvalSymlinks(sysfsPath("bus", "usb", "devices", path))
+	if err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(intf)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if isHIDDevName(entry.Name()) {
+			return filepath.Join(intf, entry.Name()), nil
+		}
+	}
+	return "", fmt.Errorf("no HID device bound to interface %s", path)
+}
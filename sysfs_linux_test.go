@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeSysfs describes the sysfs tree created by newFakeSysfs.
+type fakeSysfs struct {
+	root   string
+	usbDev string // USB device directory
+	intf   string // USB interface directory
+	hidDev string // HID device directory
+}
+
+// newFakeSysfs creates a fake sysfs tree containing a single USB keyboard
+// exposed as /dev/hidraw3 and substitutes it for sysfsRoot for the duration
+// of the test.
+func newFakeSysfs(t *testing.T) *fakeSysfs {
+	t.Helper()
+
+	root := t.TempDir()
+	fs := &fakeSysfs{root: root}
+	fs.usbDev = filepath.Join(root, "devices/pci0000:00/0000:00:14.0/usb1/1-2/1-2.4")
+	fs.intf = filepath.Join(fs.usbDev, "1-2.4:1.0")
+	fs.hidDev = filepath.Join(fs.intf, "0003:046D:C52B.0001")
+	hidraw := filepath.Join(fs.hidDev, "hidraw", "hidraw3")
+
+	mkdir := func(dir string) {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(dir, name, data string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	symlink := func(target, name string) {
+		mkdir(filepath.Dir(name))
+		if err := os.Symlink(target, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, dir := range []string{
+		"bus/usb/devices",
+		"bus/hid/drivers/hid-generic",
+		"bus/hid/drivers/logitech-djreceiver",
+		"class/hidraw",
+		"class/input",
+	} {
+		mkdir(filepath.Join(root, dir))
+	}
+	mkdir(hidraw)
+
+	write(fs.usbDev, "uevent", "DEVTYPE=usb_device\nDRIVER=usb\nPRODUCT=46d/c52b/1211\n")
+	write(fs.usbDev, "busnum", "1\n")
+	write(fs.usbDev, "devnum", "5\n")
+	write(fs.usbDev, "devpath", "2.4\n")
+	write(fs.usbDev, "idVendor", "046d\n")
+	write(fs.usbDev, "idProduct", "c52b\n")
+	write(fs.usbDev, "bcdDevice", "1211\n")
+	write(fs.usbDev, "manufacturer", "Logitech\n")
+	write(fs.usbDev, "product", "USB Receiver\n")
+	write(fs.usbDev, "speed", "12\n")
+	write(fs.usbDev, "bMaxPower", "98mA\n")
+	symlink(filepath.Join(root, "bus/usb"), filepath.Join(fs.usbDev, "subsystem"))
+	symlink(fs.usbDev, filepath.Join(root, "bus/usb/devices/1-2.4"))
+
+	write(fs.intf, "uevent", "DEVTYPE=usb_interface\nDRIVER=usbhid\n")
+	write(fs.intf, "bInterfaceNumber", "00\n")
+	symlink(filepath.Join(root, "bus/usb"), filepath.Join(fs.intf, "subsystem"))
+	symlink(fs.intf, filepath.Join(root, "bus/usb/devices/1-2.4:1.0"))
+
+	write(fs.hidDev, "uevent", "DRIVER=hid-generic\n"+
+		"HID_ID=0003:0000046D:0000C52B\n"+
+		"HID_NAME=Logitech USB Receiver\n"+
+		"HID_PHYS=usb-0000:00:14.0-2.4/input0\n"+
+		"HID_UNIQ=\n"+
+		"MODALIAS=hid:b0003g0001v0000046Dp0000C52B\n")
+	write(fs.hidDev, "report_descriptor", string(keyboardDesc))
+	symlink(filepath.Join(root, "bus/hid"), filepath.Join(fs.hidDev, "subsystem"))
+	symlink(filepath.Join(root, "bus/hid/drivers/hid-generic"), filepath.Join(fs.hidDev, "driver"))
+	symlink(fs.hidDev, filepath.Join(root, "bus/hid/devices", filepath.Base(fs.hidDev)))
+	for _, driver := range []string{"hid-generic", "logitech-djreceiver"} {
+		for _, name := range []string{"bind", "unbind", "new_id"} {
+			write(filepath.Join(root, "bus/hid/drivers", driver), name, "")
+		}
+	}
+
+	input := filepath.Join(fs.hidDev, "input", "input7")
+	mkdir(filepath.Join(input, "capabilities"))
+	mkdir(filepath.Join(input, "event5"))
+	mkdir(filepath.Join(input, "input7::capslock"))
+	write(input, "name", "Logitech USB Receiver\n")
+	write(input, "phys", "usb-0000:00:14.0-2.4/input0\n")
+	write(input, "uniq", "\n")
+	write(filepath.Join(input, "capabilities"), "ev", "120013\n")
+	write(filepath.Join(input, "capabilities"), "key", "1000000000007 ff9f207ac14057ff febeffdfffefffff fffffffffffffffe\n")
+	write(filepath.Join(input, "capabilities"), "led", "7\n")
+	write(filepath.Join(input, "event5"), "dev", "13:69\n")
+	write(filepath.Join(input, "event5"), "uevent", "MAJOR=13\nMINOR=69\nDEVNAME=input/event5\n")
+	symlink(input, filepath.Join(root, "class/input/input7"))
+	symlink(filepath.Join(input, "event5"), filepath.Join(root, "class/input/event5"))
+
+	write(hidraw, "dev", "244:3\n")
+	write(hidraw, "uevent", "MAJOR=244\nMINOR=3\nDEVNAME=hidraw3\n")
+	symlink(fs.hidDev, filepath.Join(hidraw, "device"))
+	symlink(filepath.Join(root, "class/hidraw"), filepath.Join(hidraw, "subsystem"))
+	symlink(hidraw, filepath.Join(root, "class/hidraw/hidraw3"))
+
+	saved := sysfsRoot
+	sysfsRoot = root
+	t.Cleanup(func() { sysfsRoot = saved })
+	return fs
+}
+
+// move moves the device directory from old to new and updates symbolic links
+// accordingly.
+func (fs *fakeSysfs) move(t *testing.T, old, new string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(new), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(old, new); err != nil {
+		t.Fatal(err)
+	}
+	err := filepath.Walk(fs.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			return err
+		}
+		target, err := os.Readlink(path)
+		if err != nil || !strings.HasPrefix(target+"/", old+"/") {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		return os.Symlink(new+strings.TrimPrefix(target, old), path)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []*string{&fs.usbDev, &fs.intf, &fs.hidDev} {
+		if strings.HasPrefix(*dir+"/", old+"/") {
+			*dir = new + strings.TrimPrefix(*dir, old)
+		}
+	}
+}
+
+func TestHIDSysfsDir(t *testing.T) {
+	fs := newFakeSysfs(t)
+	for _, path := range []string{"/dev/hidraw3", "1-2.4:1.0"} {
+		dir, err := hidSysfsDir(path)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if dir != fs.hidDev {
+			t.Errorf("%s: got %s, want %s", path, dir, fs.hidDev)
+		}
+	}
+	if _, err := hidSysfsDir("/dev/hidraw9"); err == nil {
+		t.Error("expected error for missing device")
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Op identifies an operation reported to a Tracer.
+type Op int
+
+const (
+	OpOpen                Op = iota + 1 // Open, OpenFirst, and OpenPath
+	OpClose                             // Close
+	OpWrite                             // Write
+	OpRead                              // Read and ReadWithTimeout
+	OpSetNonblock                       // SetNonblock
+	OpSendFeatureReport                 // SendFeatureReport
+	OpGetFeatureReport                  // GetFeatureReport
+	OpGetInputReport                    // GetInputReport
+	OpSendOutputReport                  // SendOutputReport
+	OpGetMfrStr                         // GetMfrStr
+	OpGetProductStr                     // GetProductStr
+	OpGetSerialNbr                      // GetSerialNbr
+	OpGetIndexedStr                     // GetIndexedStr
+	OpGetDeviceInfo                     // GetDeviceInfo
+	OpGetReportDescriptor               // GetReportDescriptor
+)
+
+var opNames = map[Op]string{
+	OpOpen:                "open",
+	OpClose:               "close",
+	OpWrite:               "write",
+	OpRead:                "read",
+	OpSetNonblock:         "set_nonblock",
+	OpSendFeatureReport:   "send_feature_report",
+	OpGetFeatureReport:    "get_feature_report",
+	OpGetInputReport:      "get_input_report",
+	OpSendOutputReport:    "send_output_report",
+	OpGetMfrStr:           "get_mfr_str",
+	OpGetProductStr:       "get_product_str",
+	OpGetSerialNbr:        "get_serial_nbr",
+	OpGetIndexedStr:       "get_indexed_str",
+	OpGetDeviceInfo:       "get_device_info",
+	OpGetReportDescriptor: "get_report_descriptor",
+}
+
+func (op Op) String() string {
+	if name, ok := opNames[op]; ok {
+		return name
+	}
+	return fmt.Sprintf("Op(%d)", int(op))
+}
+
+// TraceEvent describes a completed operation.
+type TraceEvent struct {
+	Op        Op            // Operation
+	Path      string        // Device Path
+	VendorID  uint16        // Device Vendor ID (Open and OpenFirst only)
+	ProductID uint16        // Device Product ID (Open and OpenFirst only)
+	Str       string        // Serial Number Requested (Open) or String Returned
+	SerialNbr string        // Device Serial Number, if Known
+	Data      []byte        // Report or Descriptor Transferred
+	N         int           // Number of Bytes Transferred
+	Err       error         // Error Returned by Operation
+	Duration  time.Duration // Duration of Operation
+}
+
+// Tracer is called after each operation on a Device completes. Data
+// referenced by the event is only valid for the duration of the call, and
+// Trace may be called concurrently by multiple goroutines.
+type Tracer interface {
+	Trace(e *TraceEvent)
+}
+
+// TracerFunc is an adapter to allow the use of ordinary functions as a Tracer.
+type TracerFunc func(e *TraceEvent)
+
+// Trace calls f(e).
+func (f TracerFunc) Trace(e *TraceEvent) {
+	f(e)
+}
+
+// MultiTracer returns a Tracer which calls each of the given tracers in turn.
+func MultiTracer(tracers ...Tracer) Tracer {
+	ts := make(multiTracer, 0, len(tracers))
+	for _, t := range tracers {
+		if t != nil {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+type multiTracer []Tracer
+
+func (ts multiTracer) Trace(e *TraceEvent) {
+	for _, t := range ts {
+		t.Trace(e)
+	}
+}
+
+var (
+	tracerMu sync.Mutex
+	tracer   Tracer
+)
+
+// SetTracer sets the Tracer used for devices opened by Open, OpenFirst, and
+// OpenPath. Devices which are already open are not affected. If t is nil,
+// tracing is disabled.
+func SetTracer(t Tracer) {
+	tracerMu.Lock()
+	defer tracerMu.Unlock()
+	tracer = t
+}
+
+func getTracer() Tracer {
+	tracerMu.Lock()
+	defer tracerMu.Unlock()
+	return tracer
+}
+
+// SetTracer sets the Tracer used for operations on d, replacing the Tracer
+// set when the device was opened, if any. If t is nil, tracing is disabled.
+// SetTracer must not be called concurrently with other methods on d.
+func (d *Device) SetTracer(t Tracer) {
+	if td, ok := d.device.(*tracedDevice); ok {
+		if t == nil {
+			d.device = td.device
+		} else {
+			td.tracer = t
+		}
+		return
+	}
+	if t == nil {
+		return
+	}
+	var path, serial string
+	if info, err := d.device.getDeviceInfo(); err == nil {
+		path, serial = info.Path, info.SerialNbr
+	}
+	d.device = &tracedDevice{device: d.device, tracer: t, path: path, serial: serial}
+}
+
+// baseDevice returns the device wrapped by dev if it is traced.
+func baseDevice(dev device) device {
+	if td, ok := dev.(*tracedDevice); ok {
+		return td.device
+	}
+	return dev
+}
+
+// traceOpen reports the result of opening a device to the package Tracer
+// and enables tracing for d. If path is empty, it is determined from d.
+func traceOpen(start time.Time, path string, vid, pid uint16, serial *string, d *Device, err error) (*Device, error) {
+	t := getTracer()
+	if t == nil {
+		return d, err
+	}
+	e := &TraceEvent{Op: OpOpen, Path: path, VendorID: vid, ProductID: pid, Err: err}
+	if serial != nil {
+		e.Str = *serial
+	}
+	if err == nil {
+		// The serial number is determined once so that it is available
+		// to the Tracer for each operation on the device.
+		if info, err := d.device.getDeviceInfo(); err == nil {
+			if path == "" {
+				e.Path = info.Path
+			}
+			e.SerialNbr = info.SerialNbr
+		}
+		d.device = &tracedDevice{device: d.device, tracer: t, path: e.Path, serial: e.SerialNbr}
+	}
+	e.Duration = time.Since(start)
+	t.Trace(e)
+	return d, err
+}
+
+// tracedDevice reports operations on a device to a Tracer.
+type tracedDevice struct {
+	device
+	tracer Tracer
+	path   string
+	serial string
+}
+
+func (d *tracedDevice) trace(op Op, start time.Time, data []byte, n int, str string, err error) {
+	e := &TraceEvent{Op: op, Path: d.path, Str: str, SerialNbr: d.serial, N: n, Err: err, Duration: time.Since(start)}
+	if n > 0 && n <= len(data) {
+		e.Data = data[:n]
+	}
+	d.tracer.Trace(e)
+}
+
+// traceIO performs an operation which transfers len(p) bytes.
+func (d *tracedDevice) traceIO(op Op, p []byte, fn func([]byte) (int, error)) (int, error) {
+	start := time.Now()
+	n, err := fn(p)
+	d.trace(op, start, p, n, "", err)
+	return n, err
+}
+
+// traceStr performs an operation which returns a string.
+func (d *tracedDevice) traceStr(op Op, fn func() (string, error)) (string, error) {
+	start := time.Now()
+	s, err := fn()
+	d.trace(op, start, nil, 0, s, err)
+	return s, err
+}
+
+func (d *tracedDevice) write(p []byte) (int, error) {
+	return d.traceIO(OpWrite, p, d.device.write)
+}
+
+func (d *tracedDevice) readTimeout(p []byte, timeout time.Duration) (int, error) {
+	return d.traceIO(OpRead, p, func(p []byte) (int, error) {
+		return d.device.readTimeout(p, timeout)
+	})
+}
+
+func (d *tracedDevice) read(p []byte) (int, error) {
+	return d.traceIO(OpRead, p, d.device.read)
+}
+
+func (d *tracedDevice) readTimestamped(p []byte) (int, time.Time, error) {
+	var ts time.Time
+	n, err := d.traceIO(OpRead, p, func(p []byte) (n int, err error) {
+		n, ts, err = d.device.readTimestamped(p)
+		return n, err
+	})
+	return n, ts, err
+}
+
+func (d *tracedDevice) setNonblock(nonblocking bool) error {
+	start := time.Now()
+	err := d.device.setNonblock(nonblocking)
+	d.trace(OpSetNonblock, start, nil, 0, "", err)
+	return err
+}
+
+func (d *tracedDevice) sendFeatureReport(p []byte) (int, error) {
+	return d.traceIO(OpSendFeatureReport, p, d.device.sendFeatureReport)
+}
+
+func (d *tracedDevice) getFeatureReport(p []byte) (int, error) {
+	return d.traceIO(OpGetFeatureReport, p, d.device.getFeatureReport)
+}
+
+func (d *tracedDevice) getInputReport(p []byte) (int, error) {
+	return d.traceIO(OpGetInputReport, p, d.device.getInputReport)
+}
+
+func (d *tracedDevice) sendOutputReport(p []byte) (int, error) {
+	return d.traceIO(OpSendOutputReport, p, d.device.sendOutputReport)
+}
+
+func (d *tracedDevice) close() error {
+	start := time.Now()
+	err := d.device.close()
+	d.trace(OpClose, start, nil, 0, "", err)
+	return err
+}
+
+func (d *tracedDevice) getMfrStr() (string, error) {
+	return d.traceStr(OpGetMfrStr, d.device.getMfrStr)
+}
+
+func (d *tracedDevice) getProductStr() (string, error) {
+	return d.traceStr(OpGetProductStr, d.device.getProductStr)
+}
+
+func (d *tracedDevice) getSerialNbr() (string, error) {
+	return d.traceStr(OpGetSerialNbr, d.device.getSerialNbr)
+}
+
+func (d *tracedDevice) getIndexedStr(index int) (string, error) {
+	return d.traceStr(OpGetIndexedStr, func() (string, error) {
+		return d.device.getIndexedStr(index)
+	})
+}
+
+func (d *tracedDevice) getDeviceInfo() (*DeviceInfo, error) {
+	start := time.Now()
+	info, err := d.device.getDeviceInfo()
+	d.trace(OpGetDeviceInfo, start, nil, 0, "", err)
+	return info, err
+}
+
+func (d *tracedDevice) getReportDescriptor(p []byte) (int, error) {
+	return d.traceIO(OpGetReportDescriptor, p, d.device.getReportDescriptor)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeDevice is a device which echoes reports written to it.
+type fakeDevice struct {
+	last []byte
+}
+
+func (d *fakeDevice) write(p []byte) (int, error) {
+	d.last = append([]byte(nil), p...)
+	return len(p), nil
+}
+
+func (d *fakeDevice) readTimeout(p []byte, timeout time.Duration) (int, error) {
+	if d.last == nil {
+		return 0, ErrTimeout
+	}
+	n := copy(p, d.last)
+	d.last = nil
+	return n, nil
+}
+
+func (d *fakeDevice) read(p []byte) (int, error) { return d.readTimeout(p, -1) }
+func (d *fakeDevice) setNonblock(bool) error     { return nil }
+func (d *fakeDevice) readTimestamped(p []byte) (int, time.Time, error) {
+	n, err := d.read(p)
+	return n, time.Now(), err
+}
+func (d *fakeDevice) sendFeatureReport(p []byte) (int, error) { return d.write(p) }
+func (d *fakeDevice) getFeatureReport(p []byte) (int, error)  { return d.read(p) }
+func (d *fakeDevice) getInputReport(p []byte) (int, error)    { return d.read(p) }
+func (d *fakeDevice) sendOutputReport(p []byte) (int, error)  { return d.write(p) }
+func (d *fakeDevice) close() error                            { return nil }
+func (d *fakeDevice) getMfrStr() (string, error)              { return "Mfr", nil }
+func (d *fakeDevice) getProductStr() (string, error)          { return "Product", nil }
+func (d *fakeDevice) getSerialNbr() (string, error)           { return "0001", nil }
+func (d *fakeDevice) getIndexedStr(int) (string, error)       { return "", errors.New("not supported") }
+func (d *fakeDevice) getReportDescriptor(p []byte) (int, error) {
+	return 0, errors.New("not supported")
+}
+func (d *fakeDevice) lastError() error     { return nil }
+func (d *fakeDevice) lastReadError() error { return nil }
+func (d *fakeDevice) getDeviceInfo() (*DeviceInfo, error) {
+	return &DeviceInfo{Path: "/dev/fake0", SerialNbr: "0001"}, nil
+}
+
+func TestDeviceSetTracer(t *testing.T) {
+	var events []TraceEvent
+	d := &Device{device: &fakeDevice{}}
+	d.SetTracer(TracerFunc(func(e *TraceEvent) {
+		ev := *e
+		ev.Data = append([]byte(nil), e.Data...)
+		events = append(events, ev)
+	}))
+
+	report := []byte{0x01, 0x02, 0x03}
+	if _, err := d.Write(report); err != nil {
+		t.Fatal(err)
+	}
+	p := make([]byte, 8)
+	if _, err := d.ReadWithTimeout(p, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.ReadWithTimeout(p, 0); err != ErrTimeout {
+		t.Fatalf("got %v, want %v", err, ErrTimeout)
+	}
+	if _, err := d.GetIndexedStr(1); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Op{OpWrite, OpRead, OpRead, OpGetIndexedStr, OpClose}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, e := range events {
+		if e.Op != want[i] || e.Path != "/dev/fake0" {
+			t.Errorf("event %d: got %v (%s), want %v (/dev/fake0)", i, e.Op, e.Path, want[i])
+		}
+		if e.SerialNbr != "0001" {
+			t.Errorf("event %d: got serial number %q, want %q", i, e.SerialNbr, "0001")
+		}
+	}
+	if !bytes.Equal(events[0].Data, report) || !bytes.Equal(events[1].Data, report) {
+		t.Errorf("got data %x and %x, want %x", events[0].Data, events[1].Data, report)
+	}
+	if events[2].Err != ErrTimeout || events[2].Data != nil {
+		t.Errorf("got %v, %x; want %v, nil", events[2].Err, events[2].Data, ErrTimeout)
+	}
+	if events[3].Err == nil {
+		t.Error("expected error")
+	}
+
+	d.SetTracer(nil)
+	if _, ok := d.device.(*tracedDevice); ok {
+		t.Error("device is still traced")
+	}
+}
+
+// descDevice is a fakeDevice which counts reads of its report descriptor.
+type descDevice struct {
+	fakeDevice
+	reads int
+}
+
+func (d *descDevice) getReportDescriptor(p []byte) (int, error) {
+	d.reads++
+	desc := []byte{
+		0x05, 0x01, // Usage Page (Generic Desktop)
+		0x09, 0x06, // Usage (Keyboard)
+		0xa1, 0x01, // Collection (Application)
+		0xc0, //       End Collection
+	}
+	return copy(p, desc), nil
+}
+
+func TestDeviceGetDeviceInfoTrace(t *testing.T) {
+	var events []Op
+	dd := &descDevice{}
+	d := &Device{device: dd}
+	d.SetTracer(TracerFunc(func(e *TraceEvent) {
+		events = append(events, e.Op)
+	}))
+
+	for i := 0; i < 2; i++ {
+		info, err := d.GetDeviceInfo()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(info.Collections) != 1 || info.Collections[0].Usage != 0x06 {
+			t.Errorf("got collections %+v", info.Collections)
+		}
+	}
+	if dd.reads != 1 {
+		t.Errorf("got %d report descriptor reads, want 1", dd.reads)
+	}
+	want := []Op{OpGetDeviceInfo, OpGetDeviceInfo}
+	if len(events) != len(want) {
+		t.Fatalf("got events %v, want %v", events, want)
+	}
+	for i, op := range events {
+		if op != want[i] {
+			t.Errorf("event %d: got %v, want %v", i, op, want[i])
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Bus types defined in linux/input.h.
+const (
+	busUSB       = 0x03
+	busBluetooth = 0x05
+	busI2C       = 0x18
+	busSPI       = 0x1c
+)
+
+// linuxBus returns the Linux bus type corresponding to t.
+func linuxBus(t BusType) (uint16, error) {
+	switch t {
+	case BusUSB:
+		return busUSB, nil
+	case BusBluetooth:
+		return busBluetooth, nil
+	case BusI2C:
+		return busI2C, nil
+	case BusSPI:
+		return busSPI, nil
+	}
+	return 0, fmt.Errorf("unsupported bus type: %v", t)
+}
+
+// UdevOptions describes the udev rules generated by UdevRules.
+type UdevOptions struct {
+	Mode    os.FileMode // Device Node Mode (MODE); zero is omitted
+	Group   string      // Device Node Group (GROUP); empty is omitted
+	Uaccess bool        // Grant Access to Active Seat (TAG+="uaccess")
+	Symlink string      // Symlink Name Prefix (SYMLINK); empty is omitted
+	Hidraw  bool        // Generate Rules for hidraw Backend
+	Libusb  bool        // Generate Rules for libusb Backend
+}
+
+// UdevRules returns udev rules granting access to the devices described by
+// devs. Devices are matched by bus type, vendor ID, product ID, and serial
+// number; VendorIDAny, ProductIDAny, and an empty serial number match any
+// device. Duplicate rules, such as those generated for devices with multiple
+// top-level collections, are removed.
+//
+// Rules for the hidraw backend match hidraw device nodes, whereas rules for
+// the libusb backend match USB device nodes (/dev/bus/usb). The libusb
+// backend only supports USB devices.
+//
+// If opts.Symlink is not empty, a symbolic link named by the prefix followed
+// by the serial number and interface number (e.g. prefix-ABC123-if00) is
+// created for each USB hidraw device, and by the prefix followed by the
+// serial number for each USB device. Symbolic links are omitted for devices
+// without a serial number and for other bus types, whose serial numbers are
+// not available to udev.
+//
+// Rules using TAG+="uaccess" must be installed in a file ordered before
+// 73-seat-late.rules, e.g. /etc/udev/rules.d/70-hid.rules.
+//
+// Serial numbers are reported by devices and may not be safely written to a
+// rule. An error is returned if a serial number contains quotation marks,
+// backslashes, control characters, or glob metacharacters, which would
+// otherwise add keys to the rule or match other devices, or if opts.Group or
+// opts.Symlink contains quotation marks, backslashes, or control characters.
+func UdevRules(devs []*DeviceInfo, opts *UdevOptions) ([]string, error) {
+	var rules []string
+	seen := make(map[string]bool)
+	add := func(r ...string) {
+		for _, rule := range r {
+			if !seen[rule] {
+				seen[rule] = true
+				rules = append(rules, rule)
+			}
+		}
+	}
+	for _, info := range devs {
+		if opts.Hidraw {
+			r, err := udevRule(info, false, opts)
+			if err != nil {
+				return nil, err
+			}
+			add(r...)
+		}
+		if opts.Libusb && (info.BusType == BusUSB || info.BusType == BusUnknown) {
+			r, err := udevRule(info, true, opts)
+			if err != nil {
+				return nil, err
+			}
+			add(r...)
+		}
+	}
+	return rules, nil
+}
+
+// WriteUdevRules writes the rules returned by UdevRules to w, one per line.
+func WriteUdevRules(w io.Writer, devs []*DeviceInfo, opts *UdevOptions) error {
+	rules, err := UdevRules(devs, opts)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if _, err := fmt.Fprintln(w, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUdevValue returns an error if s, described by name, cannot be safely
+// quoted as the value of a udev rule key. If pattern is true, s is matched
+// against device attributes and glob metacharacters are also rejected.
+func checkUdevValue(name, s string, pattern bool) error {
+	for _, r := range s {
+		if r == '"' || r == '\\' || unicode.IsControl(r) || (pattern && strings.ContainsRune("*?[]|", r)) {
+			return fmt.Errorf("invalid %s for udev rule: %q", name, s)
+		}
+	}
+	return nil
+}
+
+// udevRule returns the udev rules for the device described by info. If usbfs is
+// true, the rule matches the USB device node used by the libusb backend
+// rather than the hidraw device node.
+func udevRule(info *DeviceInfo, usbfs bool, opts *UdevOptions) ([]string, error) {
+	if err := checkUdevValue("serial number", info.SerialNbr, true); err != nil {
+		return nil, err
+	}
+	if err := checkUdevValue("group", opts.Group, false); err != nil {
+		return nil, err
+	}
+	if err := checkUdevValue("symlink prefix", opts.Symlink, false); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	usb := info.BusType == BusUSB || usbfs
+	switch {
+	case usbfs:
+		// Match the attributes of the USB device itself rather than
+		// those of a parent device.
+		keys = append(keys, `SUBSYSTEM=="usb"`, `ENV{DEVTYPE}=="usb_device"`)
+		if info.VendorID != VendorIDAny {
+			keys = append(keys, fmt.Sprintf(`ATTR{idVendor}=="%04x"`, info.VendorID))
+		}
+		if info.ProductID != ProductIDAny {
+			keys = append(keys, fmt.Sprintf(`ATTR{idProduct}=="%04x"`, info.ProductID))
+		}
+		if info.SerialNbr != "" {
+			keys = append(keys, fmt.Sprintf(`ATTR{serial}=="%s"`, info.SerialNbr))
+		}
+	case usb:
+		// Attributes of the parent USB device must match the same
+		// device; udev does not combine ATTRS from different parents.
+		keys = append(keys, `SUBSYSTEM=="hidraw"`)
+		if info.VendorID != VendorIDAny {
+			keys = append(keys, fmt.Sprintf(`ATTRS{idVendor}=="%04x"`, info.VendorID))
+		}
+		if info.ProductID != ProductIDAny {
+			keys = append(keys, fmt.Sprintf(`ATTRS{idProduct}=="%04x"`, info.ProductID))
+		}
+		if info.SerialNbr != "" {
+			keys = append(keys, fmt.Sprintf(`ATTRS{serial}=="%s"`, info.SerialNbr))
+		}
+	default:
+		// Only USB devices provide idVendor and idProduct attributes;
+		// match the name of the parent HID device instead.
+		bus := "*"
+		if n, err := linuxBus(info.BusType); err == nil {
+			bus = fmt.Sprintf("%04X", n)
+		}
+		id := func(n uint16) string {
+			if n == 0 {
+				return "*"
+			}
+			return fmt.Sprintf("%04X", n)
+		}
+		keys = append(keys, `SUBSYSTEM=="hidraw"`,
+			fmt.Sprintf(`KERNELS=="%s:%s:%s.*"`, bus, id(info.VendorID), id(info.ProductID)))
+	}
+
+	match := append([]string(nil), keys...)
+	if opts.Mode != 0 {
+		keys = append(keys, fmt.Sprintf(`MODE="%04o"`, opts.Mode.Perm()))
+	}
+	if opts.Group != "" {
+		keys = append(keys, fmt.Sprintf(`GROUP="%s"`, opts.Group))
+	}
+	if opts.Uaccess {
+		keys = append(keys, `TAG+="uaccess"`)
+	}
+	if opts.Symlink == "" || !usb {
+		return []string{strings.Join(keys, ", ")}, nil
+	}
+
+	// Symbolic links are created by a separate rule so that devices lacking
+	// a serial number are still granted access.
+	link := fmt.Sprintf(`SYMLINK+="%s$env{ID_SERIAL_SHORT}-if$env{ID_USB_INTERFACE_NUM}"`, opts.Symlink)
+	if usbfs {
+		link = fmt.Sprintf(`SYMLINK+="%s$env{ID_SERIAL_SHORT}"`, opts.Symlink)
+	}
+	return []string{
+		strings.Join(append(keys, `IMPORT{builtin}="usb_id"`), ", "),
+		strings.Join(append(match, `ENV{ID_SERIAL_SHORT}=="?*"`, link), ", "),
+	}, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package hid
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestUdevRules(t *testing.T) {
+	receiver := &DeviceInfo{VendorID: 0x046d, ProductID: 0xc52b, BusType: BusUSB}
+	tests := []struct {
+		name string
+		devs []*DeviceInfo
+		opts UdevOptions
+		want []string
+	}{
+		{
+			name: "Hidraw",
+			devs: []*DeviceInfo{receiver, receiver},
+			opts: UdevOptions{Mode: 0660, Uaccess: true, Hidraw: true},
+			want: []string{
+				`SUBSYSTEM=="hidraw", ATTRS{idVendor}=="046d", ATTRS{idProduct}=="c52b", MODE="0660", TAG+="uaccess"`,
+			},
+		},
+		{
+			name: "Libusb",
+			devs: []*DeviceInfo{{VendorID: 0x046d, SerialNbr: "ABC123", BusType: BusUSB}},
+			opts: UdevOptions{Mode: 0660, Group: "plugdev", Libusb: true},
+			want: []string{
+				`SUBSYSTEM=="usb", ENV{DEVTYPE}=="usb_device", ATTR{idVendor}=="046d", ATTR{serial}=="ABC123", MODE="0660", GROUP="plugdev"`,
+			},
+		},
+		{
+			name: "Bluetooth",
+			devs: []*DeviceInfo{{VendorID: 0x054c, ProductID: 0x09cc, BusType: BusBluetooth}},
+			opts: UdevOptions{Uaccess: true, Symlink: "ds4-", Hidraw: true, Libusb: true},
+			want: []string{
+				`SUBSYSTEM=="hidraw", KERNELS=="0005:054C:09CC.*", TAG+="uaccess"`,
+			},
+		},
+		{
+			name: "Symlink",
+			devs: []*DeviceInfo{receiver},
+			opts: UdevOptions{Mode: 0600, Symlink: "receiver-", Hidraw: true, Libusb: true},
+			want: []string{
+				`SUBSYSTEM=="hidraw", ATTRS{idVendor}=="046d", ATTRS{idProduct}=="c52b", MODE="0600", IMPORT{builtin}="usb_id"`,
+				`SUBSYSTEM=="hidraw", ATTRS{idVendor}=="046d", ATTRS{idProduct}=="c52b", ENV{ID_SERIAL_SHORT}=="?*", SYMLINK+="receiver-$env{ID_SERIAL_SHORT}-if$env{ID_USB_INTERFACE_NUM}"`,
+				`SUBSYSTEM=="usb", ENV{DEVTYPE}=="usb_device", ATTR{idVendor}=="046d", ATTR{idProduct}=="c52b", MODE="0600", IMPORT{builtin}="usb_id"`,
+				`SUBSYSTEM=="usb", ENV{DEVTYPE}=="usb_device", ATTR{idVendor}=="046d", ATTR{idProduct}=="c52b", ENV{ID_SERIAL_SHORT}=="?*", SYMLINK+="receiver-$env{ID_SERIAL_SHORT}"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UdevRules(tt.devs, &tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUdevRulesInvalid(t *testing.T) {
+	for _, serial := range []string{
+		`ABC" RUN+="/bin/sh -c 'id > /tmp/pwned'`,
+		`ABC\"`,
+		"ABC\n",
+		"*",
+		"ABC?",
+		"[A-Z]*",
+		"ABC|DEF",
+	} {
+		devs := []*DeviceInfo{{VendorID: 0x046d, ProductID: 0xc52b, SerialNbr: serial, BusType: BusUSB}}
+		if rules, err := UdevRules(devs, &UdevOptions{Hidraw: true, Libusb: true}); err == nil {
+			t.Errorf("serial %q: got %q, want error", serial, rules)
+		}
+	}
+
+	devs := []*DeviceInfo{{VendorID: 0x046d, ProductID: 0xc52b, BusType: BusUSB}}
+	for _, opts := range []UdevOptions{
+		{Group: `plugdev", RUN+="/bin/true`, Hidraw: true},
+		{Symlink: `dev", RUN+="/bin/true", SYMLINK+="`, Hidraw: true},
+	} {
+		if rules, err := UdevRules(devs, &opts); err == nil {
+			t.Errorf("%+v: got %q, want error", opts, rules)
+		}
+	}
+}
+
+func TestWriteUdevRules(t *testing.T) {
+	var buf bytes.Buffer
+	devs := []*DeviceInfo{{VendorID: 0x046d, ProductID: 0xc52b, BusType: BusUSB}}
+	if err := WriteUdevRules(&buf, devs, &UdevOptions{Uaccess: true, Hidraw: true}); err != nil {
+		t.Fatal(err)
+	}
+	want := `SUBSYSTEM=="hidraw", ATTRS{idVendor}=="046d", ATTRS{idProduct}=="c52b", TAG+="uaccess"` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
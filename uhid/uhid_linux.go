@@ -0,0 +1,427 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package uhid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/sstallion/go-hid"
+	"golang.org/x/sys/unix"
+)
+
+// DevicePath is the path of the uhid character device.
+var DevicePath = "/dev/uhid"
+
+// Event types and sizes. See include/uapi/linux/uhid.h.
+const (
+	evDestroy        = 1
+	evStart          = 2
+	evStop           = 3
+	evOpen           = 4
+	evClose          = 5
+	evOutput         = 6
+	evGetReport      = 9
+	evGetReportReply = 10
+	evCreate2        = 11
+	evInput2         = 12
+	evSetReport      = 13
+	evSetReportReply = 14
+
+	dataMax       = 4096 // UHID_DATA_MAX
+	descriptorMax = 4096 // HID_MAX_DESCRIPTOR_SIZE
+
+	// eventSize is sizeof(struct uhid_event); the largest member of the
+	// union is struct uhid_create2_req.
+	eventSize = 4 + 128 + 64 + 64 + 2 + 2 + 4*4 + descriptorMax
+)
+
+// Report types (enum uhid_report_type).
+const (
+	reportFeature = 0
+	reportOutput  = 1
+	reportInput   = 2
+)
+
+// Linux bus types. See include/uapi/linux/input.h.
+const (
+	busUSB       = 0x03
+	busBluetooth = 0x05
+	busI2C       = 0x18
+	busSPI       = 0x1c
+)
+
+// nativeEndian is the byte order of the host, which is used by the uhid
+// protocol.
+var nativeEndian binary.ByteOrder = binary.LittleEndian
+
+func init() {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 0 {
+		nativeEndian = binary.BigEndian
+	}
+}
+
+// Config describes a virtual HID device.
+type Config struct {
+	Name             string      // Device Name
+	Phys             string      // Physical Location (default unique per device)
+	Uniq             string      // Unique Identifier (Serial Number)
+	BusType          hid.BusType // Underlying Bus Type (default USB)
+	VendorID         uint16      // Device Vendor ID
+	ProductID        uint16      // Device Product ID
+	ReleaseNbr       uint16      // Device Version Number
+	Country          uint32      // Country Code
+	ReportDescriptor []byte      // Report Descriptor
+
+	// GetReport, if not nil, is called to handle GET_REPORT requests for
+	// the feature or input report with the given ID. The returned report
+	// must begin with the report ID. If GetReport is nil or returns an
+	// error, the request fails with EIO.
+	GetReport func(typ hid.ReportType, id byte) ([]byte, error)
+
+	// SetReport, if not nil, is called to handle SET_REPORT requests for
+	// the feature or output report with the given ID. If SetReport is nil
+	// or returns an error, the request fails with EIO.
+	SetReport func(typ hid.ReportType, id byte, data []byte) error
+
+	// Output, if not nil, is called for each output report written to the
+	// device.
+	Output func(typ hid.ReportType, data []byte)
+}
+
+// Device is a virtual HID device.
+type Device struct {
+	file    *os.File
+	config  Config
+	started chan struct{}
+	done    chan struct{}
+	err     error // error which terminated the event loop
+}
+
+// physSeq is used to generate a unique physical location for each device,
+// which is used to locate the corresponding hidraw device.
+var physSeq uint32
+
+// Create creates a virtual HID device described by config. The device is
+// destroyed when closed.
+func Create(config Config) (*Device, error) {
+	if len(config.ReportDescriptor) > descriptorMax {
+		return nil, errors.New("report descriptor too large")
+	}
+	if config.Phys == "" {
+		config.Phys = fmt.Sprintf("go-hid/uhid/%d.%d", os.Getpid(), atomic.AddUint32(&physSeq, 1))
+	}
+
+	fd, err := unix.Open(DevicePath, unix.O_RDWR|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: DevicePath, Err: err}
+	}
+	d := &Device{
+		file:    os.NewFile(uintptr(fd), DevicePath),
+		config:  config,
+		started: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	ev, err := create2Event(&config)
+	if err != nil {
+		d.file.Close()
+		return nil, err
+	}
+	if err := d.write(ev); err != nil {
+		d.file.Close()
+		return nil, err
+	}
+	go d.loop()
+	return d, nil
+}
+
+// write writes the event ev to the uhid device.
+func (d *Device) write(ev []byte) error {
+	if _, err := d.file.Write(ev); err != nil {
+		return fmt.Errorf("uhid: %w", err)
+	}
+	return nil
+}
+
+// Input injects an input report. For devices which support multiple
+// reports, the report must begin with the report ID.
+func (d *Device) Input(report []byte) error {
+	if len(report) > dataMax {
+		return errors.New("report too large")
+	}
+	return d.write(input2Event(report))
+}
+
+// Close destroys the virtual device.
+func (d *Device) Close() error {
+	err := d.write(newEvent(evDestroy))
+	if cerr := d.file.Close(); err == nil {
+		err = cerr
+	}
+	<-d.done
+	return err
+}
+
+// Path waits for the hidraw device corresponding to the virtual device to be
+// created and returns its path. An error is returned if the device is not
+// created before timeout.
+func (d *Device) Path(timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	select {
+	case <-d.started:
+	case <-d.done:
+		return "", d.loopErr()
+	case <-time.After(timeout):
+		return "", errors.New("timeout waiting for device to start")
+	}
+	for {
+		if path, ok := findHidraw(d.config.Phys); ok {
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return "", errors.New("timeout waiting for hidraw device")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// loopErr returns the error which terminated the event loop.
+func (d *Device) loopErr() error {
+	if d.err != nil {
+		return d.err
+	}
+	return os.ErrClosed
+}
+
+// findHidraw returns the path of the hidraw device whose HID device has the
+// physical location phys.
+func findHidraw(phys string) (string, bool) {
+	matches, _ := filepath.Glob("/sys/class/hidraw/*/device/uevent")
+	for _, match := range matches {
+		b, err := os.ReadFile(match)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(b), "\n") {
+			if line == "HID_PHYS="+phys {
+				name := filepath.Base(filepath.Dir(filepath.Dir(match)))
+				return filepath.Join("/dev", name), true
+			}
+		}
+	}
+	return "", false
+}
+
+// loop reads and handles events until the device is closed.
+func (d *Device) loop() {
+	defer close(d.done)
+	var once sync.Once
+	buf := make([]byte, eventSize)
+	for {
+		n, err := d.file.Read(buf)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				d.err = fmt.Errorf("uhid: %w", err)
+			}
+			return
+		}
+		if n < 4 {
+			continue
+		}
+		ev := buf[:n]
+		switch nativeEndian.Uint32(ev) {
+		case evStart:
+			once.Do(func() { close(d.started) })
+		case evOutput:
+			if typ, data, ok := parseOutput(ev); ok && d.config.Output != nil {
+				d.config.Output(typ, data)
+			}
+		case evGetReport:
+			if reply, ok := d.getReport(ev); ok {
+				d.write(reply)
+			}
+		case evSetReport:
+			if reply, ok := d.setReport(ev); ok {
+				d.write(reply)
+			}
+		}
+	}
+}
+
+func (d *Device) getReport(ev []byte) ([]byte, bool) {
+	id, rnum, typ, ok := parseGetReport(ev)
+	if !ok {
+		return nil, false
+	}
+	if d.config.GetReport == nil {
+		return getReportReplyEvent(id, unix.EIO, nil), true
+	}
+	data, err := d.config.GetReport(typ, rnum)
+	if err != nil || len(data) > dataMax {
+		return getReportReplyEvent(id, unix.EIO, nil), true
+	}
+	return getReportReplyEvent(id, 0, data), true
+}
+
+func (d *Device) setReport(ev []byte) ([]byte, bool) {
+	id, rnum, typ, data, ok := parseSetReport(ev)
+	if !ok {
+		return nil, false
+	}
+	if d.config.SetReport == nil || d.config.SetReport(typ, rnum, data) != nil {
+		return setReportReplyEvent(id, unix.EIO), true
+	}
+	return setReportReplyEvent(id, 0), true
+}
+
+// linuxBus returns the Linux bus type corresponding to t. BusUnknown maps to
+// USB, since the hidraw backends only enumerate devices attached to supported
+// buses.
+func linuxBus(t hid.BusType) (uint16, error) {
+	switch t {
+	case hid.BusUnknown, hid.BusUSB:
+		return busUSB, nil
+	case hid.BusBluetooth:
+		return busBluetooth, nil
+	case hid.BusI2C:
+		return busI2C, nil
+	case hid.BusSPI:
+		return busSPI, nil
+	}
+	return 0, fmt.Errorf("unsupported bus type: %v", t)
+}
+
+// reportType returns the hid.ReportType corresponding to the uhid report type
+// rtype.
+func reportType(rtype byte) hid.ReportType {
+	switch rtype {
+	case reportFeature:
+		return hid.FeatureReport
+	case reportOutput:
+		return hid.OutputReport
+	}
+	return hid.InputReport
+}
+
+// newEvent returns a zeroed event of type typ.
+func newEvent(typ uint32) []byte {
+	ev := make([]byte, eventSize)
+	nativeEndian.PutUint32(ev, typ)
+	return ev
+}
+
+// create2Event returns a UHID_CREATE2 event for config.
+func create2Event(config *Config) ([]byte, error) {
+	bus, err := linuxBus(config.BusType)
+	if err != nil {
+		return nil, err
+	}
+	ev := newEvent(evCreate2)
+	p := ev[4:]
+	copy(p[0:127], config.Name)
+	copy(p[128:191], config.Phys)
+	copy(p[192:255], config.Uniq)
+	nativeEndian.PutUint16(p[256:], uint16(len(config.ReportDescriptor)))
+	nativeEndian.PutUint16(p[258:], bus)
+	nativeEndian.PutUint32(p[260:], uint32(config.VendorID))
+	nativeEndian.PutUint32(p[264:], uint32(config.ProductID))
+	nativeEndian.PutUint32(p[268:], uint32(config.ReleaseNbr))
+	nativeEndian.PutUint32(p[272:], config.Country)
+	copy(p[276:], config.ReportDescriptor)
+	return ev, nil
+}
+
+// input2Event returns a UHID_INPUT2 event for report.
+func input2Event(report []byte) []byte {
+	ev := newEvent(evInput2)
+	nativeEndian.PutUint16(ev[4:], uint16(len(report)))
+	copy(ev[6:], report)
+	return ev
+}
+
+// parseOutput parses a UHID_OUTPUT event.
+func parseOutput(ev []byte) (hid.ReportType, []byte, bool) {
+	if len(ev) < 4+dataMax+3 {
+		return 0, nil, false
+	}
+	p := ev[4:]
+	size := int(nativeEndian.Uint16(p[dataMax:]))
+	if size > dataMax {
+		return 0, nil, false
+	}
+	return reportType(p[dataMax+2]), append([]byte(nil), p[:size]...), true
+}
+
+// parseGetReport parses a UHID_GET_REPORT event.
+func parseGetReport(ev []byte) (id uint32, rnum byte, typ hid.ReportType, ok bool) {
+	if len(ev) < 4+6 {
+		return 0, 0, 0, false
+	}
+	p := ev[4:]
+	return nativeEndian.Uint32(p), p[4], reportType(p[5]), true
+}
+
+// parseSetReport parses a UHID_SET_REPORT event.
+func parseSetReport(ev []byte) (id uint32, rnum byte, typ hid.ReportType, data []byte, ok bool) {
+	if len(ev) < 4+8 {
+		return 0, 0, 0, nil, false
+	}
+	p := ev[4:]
+	size := int(nativeEndian.Uint16(p[6:]))
+	if size > dataMax || len(p) < 8+size {
+		return 0, 0, 0, nil, false
+	}
+	return nativeEndian.Uint32(p), p[4], reportType(p[5]), append([]byte(nil), p[8:8+size]...), true
+}
+
+// getReportReplyEvent returns a UHID_GET_REPORT_REPLY event.
+func getReportReplyEvent(id uint32, errno unix.Errno, data []byte) []byte {
+	ev := newEvent(evGetReportReply)
+	p := ev[4:]
+	nativeEndian.PutUint32(p, id)
+	nativeEndian.PutUint16(p[4:], uint16(errno))
+	nativeEndian.PutUint16(p[6:], uint16(len(data)))
+	copy(p[8:], data)
+	return ev
+}
+
+// setReportReplyEvent returns a UHID_SET_REPORT_REPLY event.
+func setReportReplyEvent(id uint32, errno unix.Errno) []byte {
+	ev := newEvent(evSetReportReply)
+	p := ev[4:]
+	nativeEndian.PutUint32(p, id)
+	nativeEndian.PutUint16(p[4:], uint16(errno))
+	return ev
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package uhid
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sstallion/go-hid"
+	"golang.org/x/sys/unix"
+)
+
+// testDesc describes a vendor-defined device with an 8-byte input report
+// (ID 1), an 8-byte output report (ID 2), and an 8-byte feature report (ID 3).
+var testDesc = []byte{
+	0x06, 0x00, 0xff, // Usage Page (Vendor Defined 0xFF00)
+	0x09, 0x01, // Usage (0x01)
+	0xa1, 0x01, // Collection (Application)
+	0x15, 0x00, //   Logical Minimum (0)
+	0x26, 0xff, 0x00, //   Logical Maximum (255)
+	0x75, 0x08, //   Report Size (8)
+	0x95, 0x08, //   Report Count (8)
+	0x85, 0x01, //   Report ID (1)
+	0x09, 0x01, //   Usage (0x01)
+	0x81, 0x02, //   Input (Data,Var,Abs)
+	0x85, 0x02, //   Report ID (2)
+	0x09, 0x01, //   Usage (0x01)
+	0x91, 0x02, //   Output (Data,Var,Abs)
+	0x85, 0x03, //   Report ID (3)
+	0x09, 0x01, //   Usage (0x01)
+	0xb1, 0x02, //   Feature (Data,Var,Abs)
+	0xc0, //       End Collection
+}
+
+func TestCreate2Event(t *testing.T) {
+	ev, err := create2Event(&Config{
+		Name:             "Test Device",
+		Phys:             "test/0",
+		BusType:          hid.BusUSB,
+		VendorID:         0x1234,
+		ProductID:        0x5678,
+		ReleaseNbr:       0x0100,
+		ReportDescriptor: testDesc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ev) != 4376 {
+		t.Errorf("got event size %d, want %d", len(ev), 4376)
+	}
+	if typ := nativeEndian.Uint32(ev); typ != evCreate2 {
+		t.Errorf("got type %d, want %d", typ, evCreate2)
+	}
+	p := ev[4:]
+	if name := string(bytes.TrimRight(p[:128], "\x00")); name != "Test Device" {
+		t.Errorf("got name %q", name)
+	}
+	if phys := string(bytes.TrimRight(p[128:192], "\x00")); phys != "test/0" {
+		t.Errorf("got phys %q", phys)
+	}
+	if n := nativeEndian.Uint16(p[256:]); int(n) != len(testDesc) {
+		t.Errorf("got rd_size %d, want %d", n, len(testDesc))
+	}
+	if bus := nativeEndian.Uint16(p[258:]); bus != busUSB {
+		t.Errorf("got bus %#x, want %#x", bus, busUSB)
+	}
+	if vid, pid := nativeEndian.Uint32(p[260:]), nativeEndian.Uint32(p[264:]); vid != 0x1234 || pid != 0x5678 {
+		t.Errorf("got %04x:%04x, want 1234:5678", vid, pid)
+	}
+	if !bytes.Equal(p[276:276+len(testDesc)], testDesc) {
+		t.Error("report descriptor mismatch")
+	}
+
+	ev, err = create2Event(&Config{ReportDescriptor: testDesc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bus := nativeEndian.Uint16(ev[4+258:]); bus != busUSB {
+		t.Errorf("got default bus %#x, want %#x", bus, busUSB)
+	}
+
+	if _, err := create2Event(&Config{BusType: hid.BusType(99)}); err == nil {
+		t.Error("expected error for unsupported bus type")
+	}
+}
+
+func TestParseEvents(t *testing.T) {
+	ev := newEvent(evOutput)
+	copy(ev[4:], []byte{0x02, 0xaa})
+	nativeEndian.PutUint16(ev[4+dataMax:], 2)
+	ev[4+dataMax+2] = reportOutput
+	typ, data, ok := parseOutput(ev)
+	if !ok || typ != hid.OutputReport || !bytes.Equal(data, []byte{0x02, 0xaa}) {
+		t.Errorf("parseOutput: got (%v, %x, %v)", typ, data, ok)
+	}
+
+	ev = newEvent(evGetReport)
+	nativeEndian.PutUint32(ev[4:], 7)
+	ev[8], ev[9] = 0x03, reportFeature
+	id, rnum, typ, ok := parseGetReport(ev)
+	if !ok || id != 7 || rnum != 0x03 || typ != hid.FeatureReport {
+		t.Errorf("parseGetReport: got (%d, %d, %v, %v)", id, rnum, typ, ok)
+	}
+
+	ev = newEvent(evSetReport)
+	nativeEndian.PutUint32(ev[4:], 8)
+	ev[8], ev[9] = 0x03, reportFeature
+	nativeEndian.PutUint16(ev[10:], 2)
+	copy(ev[12:], []byte{0x03, 0x55})
+	id, rnum, typ, data, ok = parseSetReport(ev)
+	if !ok || id != 8 || rnum != 0x03 || typ != hid.FeatureReport || !bytes.Equal(data, []byte{0x03, 0x55}) {
+		t.Errorf("parseSetReport: got (%d, %d, %v, %x, %v)", id, rnum, typ, data, ok)
+	}
+
+	reply := getReportReplyEvent(7, unix.EIO, nil)
+	if nativeEndian.Uint32(reply[4:]) != 7 || nativeEndian.Uint16(reply[8:]) != uint16(unix.EIO) {
+		t.Error("getReportReplyEvent: unexpected encoding")
+	}
+}
+
+// createTestDevice creates a virtual device, skipping the test if the uhid
+// driver is unavailable.
+func createTestDevice(t *testing.T, config Config) *Device {
+	t.Helper()
+	d, err := Create(config)
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+		t.Skipf("uhid unavailable: %v", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { d.Close() })
+	return d
+}
+
+func TestDevice(t *testing.T) {
+	output := make(chan []byte, 1)
+	d := createTestDevice(t, Config{
+		Name:             "go-hid uhid test",
+		Uniq:             "0001",
+		BusType:          hid.BusUSB,
+		VendorID:         0x1209,
+		ProductID:        0x0001,
+		ReportDescriptor: testDesc,
+		GetReport: func(typ hid.ReportType, id byte) ([]byte, error) {
+			return []byte{id, 1, 2, 3, 4, 5, 6, 7, 8}, nil
+		},
+		Output: func(typ hid.ReportType, data []byte) {
+			output <- data
+		},
+	})
+
+	path, err := d.Path(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev, err := hid.OpenPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if s, _ := dev.GetSerialNbr(); s != "0001" {
+		t.Errorf("got serial number %q, want %q", s, "0001")
+	}
+
+	report := []byte{0x02, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0x10, 0x11}
+	if _, err := dev.Write(report); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case data := <-output:
+		if !bytes.Equal(data, report) {
+			t.Errorf("got output %x, want %x", data, report)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("timeout waiting for output report")
+	}
+
+	report = []byte{0x01, 8, 7, 6, 5, 4, 3, 2, 1}
+	if err := d.Input(report); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 64)
+	n, err := dev.ReadWithTimeout(b, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b[:n], report) {
+		t.Errorf("got input %x, want %x", b[:n], report)
+	}
+
+	b = []byte{0x03, 0, 0, 0, 0, 0, 0, 0, 0}
+	if _, err := dev.GetFeatureReport(b); err != nil {
+		t.Fatal(err)
+	}
+	if b[1] != 1 || b[8] != 8 {
+		t.Errorf("got feature report %x", b)
+	}
+}
+
+func TestDeviceDefaultConfig(t *testing.T) {
+	d := createTestDevice(t, Config{
+		Name:             "go-hid uhid default test",
+		VendorID:         0x1209,
+		ProductID:        0x0002,
+		ReportDescriptor: testDesc,
+	})
+
+	path, err := d.Path(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found *hid.DeviceInfo
+	err = hid.Enumerate(0x1209, 0x0002, func(info *hid.DeviceInfo) error {
+		if info.Path == path {
+			found = info
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == nil {
+		t.Fatalf("%s: device not enumerated", path)
+	}
+	if found.BusType != hid.BusUSB {
+		t.Errorf("got bus type %v, want %v", found.BusType, hid.BusUSB)
+	}
+}
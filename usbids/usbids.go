@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+// Package usbids resolves USB vendor and product IDs to names using the
+// usb.ids database maintained by the Linux USB Project.
+//
+// A copy of the database is embedded in the package. It is refreshed before
+// each release by issuing go generate ./usbids, which downloads the latest
+// copy from linux-usb.org; the version of the embedded copy is recorded in
+// its header. The embedded copy may be overridden by a local copy, such as the
+// one distributed with the hwdata or usbutils packages, by calling LoadSystem
+// or Load and SetDefault.
+//
+// The usb.ids database is not covered by the license of this package; it is
+// dual-licensed under GPL-2.0-or-later and BSD-3-Clause. Programs which embed
+// the database must comply with the terms of one of these licenses.
+//
+// See http://www.linux-usb.org/usb-ids.html for details.
+package usbids
+
+//go:generate go run gen.go -o usb.ids
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed usb.ids
+var embedded []byte
+
+// SystemPaths lists the locations searched by LoadSystem for a local copy of
+// the usb.ids database.
+var SystemPaths = []string{
+	"/usr/share/hwdata/usb.ids",
+	"/usr/share/misc/usb.ids",
+	"/usr/share/usb.ids",
+	"/var/lib/usbutils/usb.ids",
+}
+
+type vendor struct {
+	name     string
+	products map[uint16]string
+}
+
+// Database maps USB vendor and product IDs to names.
+type Database struct {
+	vendors map[uint16]*vendor
+}
+
+// Parse parses a database in usb.ids format read from r. It returns a
+// Database and an error, if any. Only the vendor and product list is parsed;
+// other lists contained in the database, such as device classes, are
+// ignored.
+func Parse(r io.Reader) (*Database, error) {
+	db := &Database{vendors: make(map[uint16]*vendor)}
+
+	var v *vendor
+	s := bufio.NewScanner(r)
+	for lineNbr := 1; s.Scan(); lineNbr++ {
+		line := strings.TrimRight(s.Text(), " \t\r")
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		var depth int
+		for depth < len(line) && line[depth] == '\t' {
+			depth++
+		}
+		id, name, ok := parseEntry(line[depth:])
+
+		switch depth {
+		case 0:
+			// Other lists are introduced by a keyword rather than a
+			// vendor ID and end the vendor list.
+			if !ok {
+				v = nil
+				continue
+			}
+			v = &vendor{name: name, products: make(map[uint16]string)}
+			db.vendors[id] = v
+		case 1:
+			if v == nil {
+				continue
+			}
+			if !ok {
+				return nil, fmt.Errorf("line %d: malformed product entry", lineNbr)
+			}
+			v.products[id] = name
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(db.vendors) == 0 {
+		return nil, errors.New("no vendors found")
+	}
+	return db, nil
+}
+
+// parseEntry parses an entry of the form "xxxx  name", where xxxx is a
+// hexadecimal ID.
+func parseEntry(s string) (uint16, string, bool) {
+	if len(s) < 6 || s[4] != ' ' {
+		return 0, "", false
+	}
+	id, err := strconv.ParseUint(s[:4], 16, 16)
+	if err != nil {
+		return 0, "", false
+	}
+	return uint16(id), strings.TrimSpace(s[5:]), true
+}
+
+// Load parses the database contained in the named file. It returns a Database
+// and an error, if any.
+func Load(name string) (*Database, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	db, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return db, nil
+}
+
+// LoadSystem parses the first database found in SystemPaths. It returns a
+// Database and an error, if any.
+func LoadSystem() (*Database, error) {
+	for _, name := range SystemPaths {
+		if _, err := os.Stat(name); err == nil {
+			return Load(name)
+		}
+	}
+	return nil, fmt.Errorf("usb.ids not found in %s", strings.Join(SystemPaths, ", "))
+}
+
+// Embedded returns the Database embedded in the package.
+func Embedded() *Database {
+	embeddedOnce.Do(func() {
+		var err error
+		if embeddedDB, err = Parse(bytes.NewReader(embedded)); err != nil {
+			panic("usbids: invalid embedded database: " + err.Error())
+		}
+	})
+	return embeddedDB
+}
+
+var (
+	embeddedOnce sync.Once
+	embeddedDB   *Database
+
+	defaultMu sync.RWMutex
+	defaultDB *Database
+)
+
+// Default returns the Database used by the Vendor and Product functions. If
+// SetDefault has not been called, the embedded Database is returned.
+func Default() *Database {
+	defaultMu.RLock()
+	db := defaultDB
+	defaultMu.RUnlock()
+	if db == nil {
+		return Embedded()
+	}
+	return db
+}
+
+// SetDefault changes the Database used by the Vendor and Product functions.
+// If db is nil, the embedded Database is restored.
+func SetDefault(db *Database) {
+	defaultMu.Lock()
+	defaultDB = db
+	defaultMu.Unlock()
+}
+
+// Vendor returns the name of the vendor with the given ID and a boolean
+// indicating whether the vendor was found.
+func (db *Database) Vendor(vid uint16) (string, bool) {
+	v, ok := db.vendors[vid]
+	if !ok {
+		return "", false
+	}
+	return v.name, true
+}
+
+// Product returns the name of the product with the given vendor and product
+// ID and a boolean indicating whether the product was found.
+func (db *Database) Product(vid, pid uint16) (string, bool) {
+	v, ok := db.vendors[vid]
+	if !ok {
+		return "", false
+	}
+	name, ok := v.products[pid]
+	return name, ok
+}
+
+// Vendor returns the name of the vendor with the given ID from the default
+// Database and a boolean indicating whether the vendor was found.
+func Vendor(vid uint16) (string, bool) {
+	return Default().Vendor(vid)
+}
+
+// Product returns the name of the product with the given vendor and product
+// ID from the default Database and a boolean indicating whether the product
+// was found.
+func Product(vid, pid uint16) (string, bool) {
+	return Default().Product(vid, pid)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Steven Stallion <sstallion@gmail.com>
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+// 1. Redistributions of source code must retain the above copyright
+//    notice, this list of conditions and the following disclaimer.
+// 2. Redistributions in binary form must reproduce the above copyright
+//    notice, this list of conditions and the following disclaimer in the
+//    documentation and/or other materials provided with the distribution.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+// ARE DISCLAIMED.  IN NO EVENT SHALL THE AUTHOR OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS
+// OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION)
+// HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY
+// OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
+// SUCH DAMAGE.
+
+package usbids
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testData = `# Comment
+1234  Example Vendor
+	0001  Example Product
+	0002  Another Product
+		00  Interface
+abcd  Other Vendor
+
+C 00  (Defined at Interface level)
+	01  Audio
+`
+
+func TestParse(t *testing.T) {
+	db, err := Parse(strings.NewReader(testData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		vid, pid uint16
+		vendor   string
+		product  string
+	}{
+		{0x1234, 0x0001, "Example Vendor", "Example Product"},
+		{0x1234, 0x0002, "Example Vendor", "Another Product"},
+		{0xabcd, 0x0001, "Other Vendor", ""},
+		{0x5678, 0x0001, "", ""},
+	}
+	for _, tt := range tests {
+		if name, _ := db.Vendor(tt.vid); name != tt.vendor {
+			t.Errorf("%04x: got %q, want %q", tt.vid, name, tt.vendor)
+		}
+		if name, _ := db.Product(tt.vid, tt.pid); name != tt.product {
+			t.Errorf("%04x:%04x: got %q, want %q", tt.vid, tt.pid, name, tt.product)
+		}
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	if name, ok := Vendor(0x046d); !ok || name != "Logitech, Inc." {
+		t.Errorf("got %q, %v", name, ok)
+	}
+	if name, ok := Product(0x046d, 0xc52b); !ok || name != "Unifying Receiver" {
+		t.Errorf("got %q, %v", name, ok)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "usb.ids")
+	if err := os.WriteFile(name, []byte(testData), 0644); err != nil {
+		t.Fatal(err)
+	}
+	saved := SystemPaths
+	SystemPaths = []string{filepath.Join(t.TempDir(), "missing"), name}
+	defer func() { SystemPaths = saved }()
+
+	db, err := LoadSystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetDefault(db)
+	defer SetDefault(nil)
+
+	if name, ok := Vendor(0x1234); !ok || name != "Example Vendor" {
+		t.Errorf("got %q, %v", name, ok)
+	}
+	if _, ok := Vendor(0x046d); ok {
+		t.Error("expected embedded database to be overridden")
+	}
+}
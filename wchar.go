@@ -21,7 +21,7 @@
 // OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF
 // SUCH DAMAGE.
 
-//go:build !windows
+//go:build !windows && !(linux && (purego || (nolibudev && !libusb && !systemhidapi)))
 
 package hid
 